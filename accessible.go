@@ -0,0 +1,64 @@
+package tap
+
+import (
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode"
+)
+
+// accessible enables linear rendering for screen readers. It starts enabled
+// when the ACCESSIBLE environment variable is set.
+var accessible atomic.Bool
+
+func init() { accessible.Store(os.Getenv("ACCESSIBLE") != "") }
+
+// accessibleInterval is how often spinners and progress bars repeat their
+// status in accessible mode.
+var accessibleInterval = 5 * time.Second
+
+// SetAccessible turns accessible mode on or off. In accessible mode prompts
+// print the question and choices once as plain text and announce changes on
+// new lines instead of redrawing, and spinners and progress bars print
+// periodic status lines instead of animating. No cursor movement or color is
+// used.
+func SetAccessible(on bool) { accessible.Store(on) }
+
+// IsAccessible reports whether accessible mode is on.
+func IsAccessible() bool { return accessible.Load() }
+
+// plainFrame converts a rendered frame to plain text for accessible mode:
+// styles are removed, leading bar and guide glyphs are trimmed, and lines
+// left empty are dropped.
+func plainFrame(frame string) string {
+	var lines []string
+
+	for _, line := range strings.Split(stripANSI(frame), "\n") {
+		line = strings.TrimLeftFunc(line, func(r rune) bool {
+			return unicode.IsSpace(r) || isGuideRune(r)
+		})
+		line = strings.TrimRightFunc(line, unicode.IsSpace)
+
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// isGuideRune reports whether r is a bar or border glyph, including the ASCII
+// theme's bar.
+func isGuideRune(r rune) bool {
+	return (r >= 0x2500 && r <= 0x257F) || r == '|'
+}
+
+// writeLine writes s followed by a newline, skipping empty text.
+func writeLine(out Writer, s string) {
+	if out == nil || s == "" {
+		return
+	}
+
+	_, _ = out.Write([]byte(s + "\n"))
+}
@@ -0,0 +1,155 @@
+package tap
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPlainFrame(t *testing.T) {
+	frame := gray(Bar) + "\n" + cyan(StepActive) + "  Pick a color\n" + cyan(Bar) + "  " + green(RadioActive) + " Red\n" + cyan(Bar) + "  " + dim(RadioInactive) + " " + dim("Blue") + "\n" + cyan(BarEnd)
+
+	want := StepActive + "  Pick a color\n" + RadioActive + " Red\n" + RadioInactive + " Blue"
+	if got := plainFrame(frame); got != want {
+		t.Errorf("plainFrame() = %q, want %q", got, want)
+	}
+}
+
+func TestAccessible_SelectAnnouncesChanges(t *testing.T) {
+	SetAccessible(true)
+	defer SetAccessible(false)
+
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	done := make(chan string, 1)
+
+	go func() {
+		done <- Select(context.Background(), SelectOptions[string]{
+			Message: "Pick a color",
+			Options: []SelectOption[string]{{Value: "Red"}, {Value: "Blue"}, {Value: "Green"}},
+			Input:   in,
+			Output:  out,
+		})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	in.EmitKeypress("", Key{Name: "down"})
+	time.Sleep(5 * time.Millisecond)
+	in.EmitKeypress("", Key{Name: "return"})
+
+	if got := <-done; got != "Blue" {
+		t.Fatalf("expected Blue, got %q", got)
+	}
+
+	got := strings.Join(out.Buffer, "")
+
+	if strings.Contains(got, "\x1b") {
+		t.Errorf("expected no escape sequences, got %q", got)
+	}
+
+	for _, want := range []string{"Pick a color\n", RadioInactive + " Green\n", "selected: Blue, 2 of 3\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in output, got %q", want, got)
+		}
+	}
+
+	if strings.Count(got, "Green") != 1 {
+		t.Errorf("expected choices to be printed once, got %q", got)
+	}
+}
+
+func TestAccessible_TextAnnouncesErrorsOnly(t *testing.T) {
+	SetAccessible(true)
+	defer SetAccessible(false)
+
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	done := make(chan string, 1)
+
+	go func() {
+		done <- Text(context.Background(), TextOptions{
+			Message: "Name:",
+			Validate: func(s string) error {
+				if len(s) < 2 {
+					return errors.New("too short")
+				}
+
+				return nil
+			},
+			Input:  in,
+			Output: out,
+		})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	in.EmitKeypress("a", Key{Name: "a", Rune: 'a'})
+	in.EmitKeypress("", Key{Name: "return"})
+	time.Sleep(5 * time.Millisecond)
+	in.EmitKeypress("b", Key{Name: "b", Rune: 'b'})
+	in.EmitKeypress("", Key{Name: "return"})
+
+	if got := <-done; got != "ab" {
+		t.Fatalf("expected ab, got %q", got)
+	}
+
+	got := stripANSI(strings.Join(out.Buffer, ""))
+
+	if !strings.Contains(got, "Error: too short\n") {
+		t.Errorf("expected error announcement, got %q", got)
+	}
+
+	if strings.Contains(got, CursorUp) || strings.Contains(got, "\r") {
+		t.Errorf("expected no cursor movement, got %q", got)
+	}
+
+	if !strings.Contains(got, StepSubmit+"  Name:\nab\n") {
+		t.Errorf("expected plain final frame, got %q", got)
+	}
+}
+
+func TestAccessible_SpinnerPrintsStatusLines(t *testing.T) {
+	SetAccessible(true)
+	defer SetAccessible(false)
+
+	out := NewMockWritable()
+
+	s := NewSpinner(SpinnerOptions{Output: out, Delay: time.Millisecond})
+	s.Start("Installing")
+	time.Sleep(10 * time.Millisecond)
+	s.Message("Linking")
+	s.Stop("Done", 0)
+
+	var lines []string
+
+	for _, w := range out.Buffer {
+		if !strings.HasPrefix(w, "\x1b]") { // OSC 9;4 progress reports
+			lines = append(lines, w)
+		}
+	}
+
+	want := []string{"Installing...\n", "Linking...\n", StepSubmit + "  Done\n"}
+	if strings.Join(lines, "") != strings.Join(want, "") {
+		t.Errorf("expected status lines %q, got %q", want, lines)
+	}
+}
+
+func TestAccessible_StreamSkipsRepaint(t *testing.T) {
+	SetAccessible(true)
+	defer SetAccessible(false)
+
+	out := NewMockWritable()
+
+	s := NewStream(StreamOptions{Output: out})
+	s.Start("Build")
+	s.WriteLine("step 1")
+	s.Stop("Built", 0)
+
+	got := strings.Join(out.Buffer, "")
+	if want := StepActive + "  Build\nstep 1\n" + StepSubmit + "  Built\n"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
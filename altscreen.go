@@ -0,0 +1,51 @@
+package tap
+
+import "github.com/yarlson/tap/internal/terminal"
+
+// Alternate screen buffer escape sequences.
+const (
+	altScreenEnter = terminal.AltScreenEnter
+	altScreenExit  = terminal.AltScreenExit
+	cursorHome     = "\x1b[H"
+)
+
+// leaveAltScreen restores the main screen if the prompt switched to the
+// alternate one, and reports whether it did.
+func (p *Prompt) leaveAltScreen() bool {
+	if p.exitAlt == nil {
+		return false
+	}
+
+	_, _ = p.output.Write([]byte(altScreenExit))
+	p.exitAlt()
+	p.exitAlt = nil
+
+	return true
+}
+
+// listWindow returns the first of the total items of a list to show and how
+// many to show, keeping cursor in view. In alternate-screen mode the list
+// fills the terminal height less chrome rows of surrounding content;
+// otherwise every item is shown.
+func listWindow(p *Prompt, top, cursor, total, chrome int) (start, height int) {
+	height = total
+
+	if p.opts.AltScreen {
+		if rows := getRows(p.output); rows > 0 {
+			height = min(total, max(rows-chrome, 1))
+		}
+	}
+
+	return scrollToRow(top, cursor, height, total), height
+}
+
+// listBar returns the bar glyph for row i of a list window starting at top,
+// marking the scroll thumb when the list does not fit.
+func listBar(th *Theme, i, top, height, total int) string {
+	start, length := scrollThumb(top, height, total)
+	if offset := i - top; length > 0 && offset >= start && offset < start+length {
+		return th.ScrollThumb
+	}
+
+	return th.Bar
+}
@@ -0,0 +1,125 @@
+package tap
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrompt_AltScreenLeavesFinalFrame(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	p := NewPrompt(PromptOptions{
+		Input:     in,
+		Output:    out,
+		AltScreen: true,
+		Render: func(p *Prompt) string {
+			if p.StateSnapshot() == StateSubmit {
+				return "done"
+			}
+
+			return "picking"
+		},
+	})
+
+	done := make(chan any, 1)
+
+	go func() { done <- p.Prompt(context.Background()) }()
+
+	time.Sleep(time.Millisecond)
+	in.EmitKeypress("", Key{Name: "return"})
+	<-done
+
+	frames := out.GetFrames()
+	if !strings.HasPrefix(frames[0], altScreenEnter) {
+		t.Errorf("expected the first frame on the alternate screen, got %q", frames[0])
+	}
+
+	got := strings.Join(frames, "")
+
+	exit := strings.Index(got, altScreenExit)
+	if exit < 0 {
+		t.Fatalf("expected the alternate screen to be left, got %q", got)
+	}
+
+	if want := altScreenExit + "done\r\n" + CursorShow; !strings.HasSuffix(got, want) {
+		t.Errorf("expected the final frame on the main screen, got %q", got[exit:])
+	}
+}
+
+func TestSelect_AltScreenFillsTerminalHeight(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+	out.SetSize(40, 8) // four rows for options
+
+	options := make([]SelectOption[string], 10)
+	for i := range options {
+		options[i] = SelectOption[string]{Value: fmt.Sprintf("item %d", i)}
+	}
+
+	done := make(chan string, 1)
+
+	go func() {
+		done <- Select(context.Background(), SelectOptions[string]{
+			Message:   "Pick",
+			Options:   options,
+			AltScreen: true,
+			Input:     in,
+			Output:    out,
+		})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+
+	for range 5 {
+		in.EmitKeypress("", Key{Name: "down"})
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	screen := screenOf(out)
+	for _, want := range []string{"item 2", "item 5", ScrollThumb} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("expected %q in the window, got %q", want, screen)
+		}
+	}
+
+	if strings.Contains(screen, "item 1") || strings.Contains(screen, "item 6") {
+		t.Errorf("expected a four item window, got %q", screen)
+	}
+
+	in.EmitKeypress("", Key{Name: "return"})
+
+	if got := <-done; got != "item 5" {
+		t.Errorf("expected item 5, got %q", got)
+	}
+}
+
+func TestSelect_ShowsAllOptionsWithoutAltScreen(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+	out.SetSize(40, 8)
+
+	options := make([]SelectOption[string], 10)
+	for i := range options {
+		options[i] = SelectOption[string]{Value: fmt.Sprintf("item %d", i)}
+	}
+
+	done := make(chan string, 1)
+
+	go func() {
+		done <- Select(context.Background(), SelectOptions[string]{Message: "Pick", Options: options, Input: in, Output: out})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+
+	if screen := screenOf(out); !strings.Contains(screen, "item 0") || !strings.Contains(screen, "item 9") {
+		t.Errorf("expected every option, got %q", screen)
+	}
+
+	in.EmitKeypress("", Key{Name: "return"})
+	<-done
+}
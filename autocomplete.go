@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"time"
+	"unicode"
 )
 
 // Autocomplete renders a text prompt with inline suggestions.
@@ -27,9 +29,16 @@ func Autocomplete(ctx context.Context, opts AutocompleteOptions) string {
 }
 
 type acState struct {
-	suggestions []string
+	suggestions []Suggestion
 	selected    int
 	accepted    string // value accepted via Tab
+
+	// Async suggestion bookkeeping; only touched on the event loop.
+	query   string             // input the current suggestions belong to
+	loading bool               // an async request is pending
+	err     string             // last async error, shown in place of suggestions
+	gen     int                // request generation; stale results are dropped
+	cancel  context.CancelFunc // cancels the in-flight request
 }
 
 func (st *acState) clampSelected() {
@@ -46,6 +55,8 @@ func (st *acState) clampSelected() {
 }
 
 func autocomplete(ctx context.Context, opts AutocompleteOptions) string {
+	th := resolveTheme(opts.Theme)
+
 	// Wrap validator to match PromptOptions
 	var validate func(any) error
 	if opts.Validate != nil {
@@ -63,17 +74,23 @@ func autocomplete(ctx context.Context, opts AutocompleteOptions) string {
 	state := &acState{selected: 0}
 
 	// Helper: compute suggestions respecting max
-	getSugs := func(input string) []string {
-		if opts.Suggest == nil {
+	getSugs := func(input string) []Suggestion {
+		var list []Suggestion
+
+		switch {
+		case opts.SuggestItems != nil:
+			list = slices.Clone(opts.SuggestItems(input))
+		case opts.Suggest != nil:
+			list = stringSuggestions(opts.Suggest(input))
+		default:
 			return nil
 		}
 
-		list := opts.Suggest(input)
 		if len(list) > maxResults {
-			return slices.Clone(list[:maxResults])
+			return list[:maxResults]
 		}
 
-		return slices.Clone(list)
+		return list
 	}
 
 	// Local input state
@@ -82,7 +99,90 @@ func autocomplete(ctx context.Context, opts AutocompleteOptions) string {
 		cur   int
 	)
 
-	p := NewPromptWithTracking(PromptOptions{
+	// p is assigned below; refresh only runs once the prompt exists.
+	var p *Prompt
+
+	// refresh recomputes suggestions for input. Sync sources run inline; async
+	// sources run in a goroutine after the debounce delay and post their result
+	// back onto the event loop.
+	refresh := func(input string) {
+		if opts.SuggestAsync == nil {
+			state.suggestions = getSugs(input)
+			if state.selected >= len(state.suggestions) {
+				state.selected = 0
+			}
+
+			return
+		}
+
+		if input == state.query && (state.loading || state.cancel != nil) {
+			return
+		}
+
+		if state.cancel != nil {
+			state.cancel()
+		}
+
+		parent := ctx
+		if parent == nil {
+			parent = context.Background()
+		}
+
+		reqCtx, cancel := context.WithCancel(parent)
+		state.cancel = cancel
+		state.query = input
+		state.gen++
+		state.loading = true
+		state.err = ""
+		state.suggestions = nil
+		state.selected = 0
+
+		gen := state.gen
+
+		go func() {
+			if opts.Debounce > 0 {
+				timer := time.NewTimer(opts.Debounce)
+				defer timer.Stop()
+
+				select {
+				case <-reqCtx.Done():
+					return
+				case <-timer.C:
+				}
+			}
+
+			list, err := opts.SuggestAsync(reqCtx, input)
+			if reqCtx.Err() != nil {
+				return
+			}
+
+			p.enqueue(func(_ *promptState) {
+				if gen != state.gen {
+					return
+				}
+
+				state.loading = false
+				if err != nil {
+					// Failures are not kept, so the next key retries the query
+					state.err = err.Error()
+					state.cancel()
+					state.cancel = nil
+					state.query = ""
+
+					return
+				}
+
+				if len(list) > maxResults {
+					list = list[:maxResults]
+				}
+
+				state.suggestions = list
+				state.selected = 0
+			})
+		}()
+	}
+
+	p = NewPromptWithTracking(PromptOptions{
 		Input:        opts.Input,
 		Output:       opts.Output,
 		Validate:     validate,
@@ -91,7 +191,7 @@ func autocomplete(ctx context.Context, opts AutocompleteOptions) string {
 			s := p.StateSnapshot()
 
 			// Title
-			title := gray(Bar) + "\n" + Symbol(s) + "  " + opts.Message + "\n"
+			title := th.muted(th.Bar) + "\n" + th.Symbol(s) + "  " + opts.Message + "\n"
 
 			// Display input using local state
 			var displayInput string
@@ -110,7 +210,7 @@ func autocomplete(ctx context.Context, opts AutocompleteOptions) string {
 			switch s {
 			case StateError:
 				errMsg := p.ErrorSnapshot()
-				return title + yellow(Bar) + "  " + displayInput + "\n" + yellow(BarEnd) + "  " + yellow(errMsg)
+				return title + th.warning(th.Bar) + "  " + displayInput + "\n" + th.warning(th.BarEnd) + "  " + th.warning(errMsg)
 			case StateSubmit:
 				value := ""
 				if val, ok := p.ValueSnapshot().(string); ok {
@@ -123,7 +223,7 @@ func autocomplete(ctx context.Context, opts AutocompleteOptions) string {
 				}
 				// Add a prefixed newline (gray bar) after submit to visually separate
 				// from subsequent messages, matching expectations in examples.
-				return title + gray(Bar) + valueText + "\n" + gray(Bar)
+				return title + th.muted(th.Bar) + valueText + "\n" + th.muted(th.Bar)
 			case StateCancel:
 				value := ""
 				if val, ok := p.ValueSnapshot().(string); ok {
@@ -135,32 +235,60 @@ func autocomplete(ctx context.Context, opts AutocompleteOptions) string {
 					valueText = "  " + strikethrough(dim(value))
 				}
 
-				result := title + gray(Bar) + valueText
+				result := title + th.muted(th.Bar) + valueText
 				if strings.TrimSpace(value) != "" {
-					result += "\n" + gray(Bar)
+					result += "\n" + th.muted(th.Bar)
 				}
 
 				return result
 			default:
-				if len(state.suggestions) == 0 {
-					return title + cyan(Bar) + "  " + displayInput + "\n" + cyan(BarEnd)
+				switch {
+				case state.loading:
+					return fmt.Sprintf("%s%s  %s\n%s  %s\n%s\n", title, th.active(th.Bar), displayInput, th.active(th.Bar), dim(resolveLocale(opts.Locale).Loading), th.active(th.BarEnd))
+				case state.err != "":
+					return fmt.Sprintf("%s%s  %s\n%s  %s\n%s\n", title, th.active(th.Bar), displayInput, th.active(th.Bar), th.warning(state.err), th.active(th.BarEnd))
+				case len(state.suggestions) == 0:
+					return title + th.active(th.Bar) + "  " + displayInput + "\n" + th.active(th.BarEnd)
 				}
 
 				var lines []string
 
+				query := string(inBuf)
 				for i, sg := range state.suggestions {
+					label := sg.label()
+					matches := fuzzyPositions(query, label)
+
+					var line string
 					if i == state.selected {
-						lines = append(lines, fmt.Sprintf("%s %s", green(RadioActive), sg))
+						line = fmt.Sprintf("%s %s", th.success(th.RadioActive), highlightMatches(th, label, matches, nil))
 					} else {
-						lines = append(lines, fmt.Sprintf("%s %s", dim(RadioInactive), dim(sg)))
+						line = fmt.Sprintf("%s %s", dim(th.RadioInactive), highlightMatches(th, label, matches, dim))
+					}
+
+					if sg.Hint != "" {
+						line += " " + dim(sg.Hint)
 					}
+
+					lines = append(lines, line)
 				}
 
-				sugs := strings.Join(lines, fmt.Sprintf("\n%s  ", cyan(Bar)))
+				sugs := strings.Join(lines, fmt.Sprintf("\n%s  ", th.active(th.Bar)))
 
-				return fmt.Sprintf("%s%s  %s\n%s  %s\n%s\n", title, cyan(Bar), displayInput, cyan(Bar), sugs, cyan(BarEnd))
+				return fmt.Sprintf("%s%s  %s\n%s  %s\n%s\n", title, th.active(th.Bar), displayInput, th.active(th.Bar), sugs, th.active(th.BarEnd))
 			}
 		},
+		Announce: func(_ *Prompt) string {
+			switch {
+			case state.loading:
+				return resolveLocale(opts.Locale).Loading
+			case state.err != "":
+				return state.err
+			case len(state.suggestions) == 0:
+				return ""
+			}
+
+			return fmt.Sprintf(resolveLocale(opts.Locale).SelectedAnnouncement, state.suggestions[state.selected].label(), state.selected+1, len(state.suggestions))
+		},
 	}, false)
 
 	// Initialize from InitialValue if provided
@@ -168,12 +296,17 @@ func autocomplete(ctx context.Context, opts AutocompleteOptions) string {
 		inBuf = []rune(opts.InitialValue)
 		cur = len(inBuf)
 		p.SetImmediateValue(string(inBuf))
+		refresh(string(inBuf))
+	}
 
-		state.suggestions = getSugs(string(inBuf))
-		if state.selected >= len(state.suggestions) {
-			state.selected = 0
+	// Cancel any in-flight async request once the prompt is done
+	p.On("finalize", func() {
+		if state.cancel != nil {
+			state.cancel()
 		}
-	}
+	})
+
+	enableBracketedPaste(p, opts.Output)
 
 	// Key handling: build input, manage cursor, suggestions, and accept
 	p.On("key", func(char string, key Key) {
@@ -195,6 +328,18 @@ func autocomplete(ctx context.Context, opts AutocompleteOptions) string {
 			if cur < len(inBuf) {
 				inBuf = slices.Delete(inBuf, cur, cur+1)
 			}
+		case "paste":
+			content, err := sanitizePaste(key.Content, opts.PasteNewlines, resolveLocale(opts.Locale))
+			if err != nil {
+				p.cur.Error = err.Error()
+				p.cur.State = StateError
+
+				break
+			}
+
+			pasted := []rune(content)
+			inBuf = slices.Insert(inBuf, cur, pasted...)
+			cur += len(pasted)
 		case "up":
 			if len(state.suggestions) > 0 {
 				state.selected--
@@ -207,7 +352,13 @@ func autocomplete(ctx context.Context, opts AutocompleteOptions) string {
 			}
 		case "tab":
 			if len(state.suggestions) > 0 {
-				accepted := state.suggestions[state.selected]
+				// Shell-style: first extend the input to the longest common
+				// prefix; once nothing is left to complete, accept the selection.
+				accepted := state.suggestions[state.selected].Value
+				if lcp := commonValuePrefix(state.suggestions); state.selected == 0 && extendsInput(lcp, string(inBuf)) {
+					accepted = lcp
+				}
+
 				inBuf = []rune(accepted)
 				cur = len(inBuf)
 				p.SetImmediateValue(string(inBuf))
@@ -227,11 +378,7 @@ func autocomplete(ctx context.Context, opts AutocompleteOptions) string {
 
 		// After any edit/update, reflect in value and recompute suggestions
 		p.SetImmediateValue(string(inBuf))
-
-		state.suggestions = getSugs(string(inBuf))
-		if state.selected >= len(state.suggestions) {
-			state.selected = 0
-		}
+		refresh(string(inBuf))
 
 		// If this key is return, prime the value so Prompt will submit it
 		if key.Name == "return" {
@@ -246,3 +393,228 @@ func autocomplete(ctx context.Context, opts AutocompleteOptions) string {
 
 	return ""
 }
+
+// label returns the text displayed for a suggestion.
+func (s Suggestion) label() string {
+	if s.Label != "" {
+		return s.Label
+	}
+
+	return s.Value
+}
+
+// stringSuggestions wraps plain strings as suggestions.
+func stringSuggestions(list []string) []Suggestion {
+	if len(list) == 0 {
+		return nil
+	}
+
+	out := make([]Suggestion, len(list))
+	for i, v := range list {
+		out[i] = Suggestion{Value: v}
+	}
+
+	return out
+}
+
+// commonValuePrefix returns the longest prefix shared by all suggestion values.
+func commonValuePrefix(list []Suggestion) string {
+	if len(list) == 0 {
+		return ""
+	}
+
+	prefix := []rune(list[0].Value)
+	for _, sg := range list[1:] {
+		v := []rune(sg.Value)
+
+		n := 0
+		for n < len(prefix) && n < len(v) && prefix[n] == v[n] {
+			n++
+		}
+
+		prefix = prefix[:n]
+	}
+
+	return string(prefix)
+}
+
+// extendsInput reports whether completing input to prefix adds characters
+// without changing what was typed (ignoring case).
+func extendsInput(prefix, input string) bool {
+	p, in := []rune(prefix), []rune(input)
+	if len(p) <= len(in) {
+		return false
+	}
+
+	return strings.EqualFold(string(p[:len(in)]), input)
+}
+
+// fuzzyPositions returns the rune indices in target that match query as a
+// case-insensitive subsequence, preferring a contiguous substring match.
+// It returns nil when query is empty or does not match.
+func fuzzyPositions(query, target string) []int {
+	q := lowerRunes(query)
+	t := lowerRunes(target)
+
+	if len(q) == 0 || len(q) > len(t) {
+		return nil
+	}
+
+	pos := make([]int, 0, len(q))
+
+	for start := 0; start+len(q) <= len(t); start++ {
+		if slices.Equal(t[start:start+len(q)], q) {
+			for i := range q {
+				pos = append(pos, start+i)
+			}
+
+			return pos
+		}
+	}
+
+	qi := 0
+	for ti := 0; ti < len(t) && qi < len(q); ti++ {
+		if t[ti] == q[qi] {
+			pos = append(pos, ti)
+			qi++
+		}
+	}
+
+	if qi < len(q) {
+		return nil
+	}
+
+	return pos
+}
+
+// lowerRunes lowercases s rune by rune so indices line up with []rune(s).
+func lowerRunes(s string) []rune {
+	runes := []rune(s)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+
+	return runes
+}
+
+// fuzzyScore rates a match: matches at word starts (especially the very first
+// rune) and consecutive runs score higher; gaps cost a little, capped so one
+// long jump to a word start is not punished too hard. Shorter labels win ties.
+func fuzzyScore(target string, pos []int) int {
+	runes := []rune(target)
+	score := 0
+
+	for i, p := range pos {
+		switch {
+		case p == 0:
+			score += 12
+		case strings.ContainsRune(" /-_.:", runes[p-1]):
+			score += 8
+		case i > 0 && pos[i-1] == p-1:
+			score += 6
+		default:
+			score += 2
+		}
+
+		if i > 0 {
+			score -= min(p-pos[i-1]-1, 3)
+		}
+	}
+
+	return score*10 - len(runes)
+}
+
+// FuzzyMatch keeps the items whose label contains input as a case-insensitive
+// subsequence and orders them best match first. It is a ready-made ranking
+// helper for AutocompleteOptions.SuggestItems.
+func FuzzyMatch(input string, items []Suggestion) []Suggestion {
+	if input == "" {
+		return slices.Clone(items)
+	}
+
+	type ranked struct {
+		item  Suggestion
+		score int
+	}
+
+	var matched []ranked
+
+	for _, it := range items {
+		label := it.label()
+		if pos := fuzzyPositions(input, label); pos != nil {
+			matched = append(matched, ranked{it, fuzzyScore(label, pos)})
+		}
+	}
+
+	slices.SortStableFunc(matched, func(a, b ranked) int { return b.score - a.score })
+
+	out := make([]Suggestion, len(matched))
+	for i, m := range matched {
+		out[i] = m.item
+	}
+
+	return out
+}
+
+// PrefixMatch keeps the items whose label starts with input, ignoring case,
+// in their original order.
+func PrefixMatch(input string, items []Suggestion) []Suggestion {
+	var out []Suggestion
+
+	for _, it := range items {
+		if extendsInput(it.label(), input) || strings.EqualFold(it.label(), input) {
+			out = append(out, it)
+		}
+	}
+
+	return out
+}
+
+// highlightMatches renders label with matched rune positions emphasized.
+// Unmatched runs are passed through style (nil leaves them unstyled).
+func highlightMatches(th *Theme, label string, matches []int, style func(string) string) string {
+	if style == nil {
+		style = func(s string) string { return s }
+	}
+
+	if len(matches) == 0 {
+		return style(label)
+	}
+
+	hit := make(map[int]bool, len(matches))
+	for _, m := range matches {
+		hit[m] = true
+	}
+
+	var (
+		b   strings.Builder
+		run []rune
+	)
+
+	flush := func(matched bool) {
+		if len(run) == 0 {
+			return
+		}
+
+		if matched {
+			b.WriteString(bold(th.active(string(run))))
+		} else {
+			b.WriteString(style(string(run)))
+		}
+
+		run = run[:0]
+	}
+
+	runes := []rune(label)
+	for i, r := range runes {
+		if i > 0 && hit[i] != hit[i-1] {
+			flush(hit[i-1])
+		}
+
+		run = append(run, r)
+	}
+
+	flush(hit[len(runes)-1])
+
+	return b.String()
+}
@@ -2,7 +2,10 @@ package tap
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -121,3 +124,364 @@ func TestAutocomplete_ArrowNavigationChangesSelection(t *testing.T) {
 		t.Fatalf("expected 'beta', got %q", got)
 	}
 }
+
+func TestAutocomplete_AsyncSuggestionsShowLoadingThenResults(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	release := make(chan struct{})
+
+	resultCh := make(chan string, 1)
+
+	go func() {
+		resultCh <- Autocomplete(context.Background(), AutocompleteOptions{
+			Message: "Pick:",
+			SuggestAsync: func(_ context.Context, input string) ([]Suggestion, error) {
+				<-release
+				return stringSuggestions(suggestFn([]string{"alpha", "beta"})(input)), nil
+			},
+			Input:  in,
+			Output: out,
+		})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	in.EmitKeypress("b", Key{Name: "b"})
+	time.Sleep(10 * time.Millisecond)
+
+	frames := out.GetFrames()
+	if !strings.Contains(frames[len(frames)-1], "Loading...") {
+		t.Fatalf("expected loading indicator, got %q", frames[len(frames)-1])
+	}
+
+	close(release)
+	time.Sleep(10 * time.Millisecond)
+
+	frames = out.GetFrames()
+	if last := removeANSI(frames[len(frames)-1]); !strings.Contains(last, "beta") || strings.Contains(last, "Loading...") {
+		t.Fatalf("expected async suggestions, got %q", last)
+	}
+
+	in.EmitKeypress("\t", Key{Name: "tab"})
+	time.Sleep(10 * time.Millisecond)
+	in.EmitKeypress("", Key{Name: "return"})
+
+	if got := <-resultCh; got != "beta" {
+		t.Fatalf("expected 'beta', got %q", got)
+	}
+}
+
+func TestAutocomplete_AsyncCancelsStaleRequestsAndDebounces(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	var (
+		mu        sync.Mutex
+		calls     []string
+		cancelled int
+	)
+
+	resultCh := make(chan string, 1)
+
+	go func() {
+		resultCh <- Autocomplete(context.Background(), AutocompleteOptions{
+			Message:  "Pick:",
+			Debounce: 20 * time.Millisecond,
+			SuggestAsync: func(ctx context.Context, input string) ([]Suggestion, error) {
+				mu.Lock()
+				calls = append(calls, input)
+				mu.Unlock()
+
+				select {
+				case <-ctx.Done():
+					mu.Lock()
+					cancelled++
+					mu.Unlock()
+
+					return nil, ctx.Err()
+				case <-time.After(30 * time.Millisecond):
+				}
+
+				return []Suggestion{{Value: input + "-result"}}, nil
+			},
+			Input:  in,
+			Output: out,
+		})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	// Typed faster than the debounce: only the final input is requested
+	in.EmitKeypress("a", Key{Name: "a"})
+	in.EmitKeypress("b", Key{Name: "b"})
+	time.Sleep(30 * time.Millisecond)
+	// Typing again while the request is in flight cancels it
+	in.EmitKeypress("c", Key{Name: "c"})
+	time.Sleep(80 * time.Millisecond)
+
+	mu.Lock()
+	gotCalls := slices.Clone(calls)
+	gotCancelled := cancelled
+	mu.Unlock()
+
+	if !slices.Equal(gotCalls, []string{"ab", "abc"}) {
+		t.Errorf("expected requests for [ab abc], got %v", gotCalls)
+	}
+
+	if gotCancelled != 1 {
+		t.Errorf("expected the stale request to be cancelled, got %d cancellations", gotCancelled)
+	}
+
+	frames := out.GetFrames()
+	if last := removeANSI(frames[len(frames)-1]); !strings.Contains(last, "abc-result") || strings.Contains(last, "ab-result") {
+		t.Errorf("expected only fresh results, got %q", last)
+	}
+
+	in.EmitKeypress("", Key{Name: "return"})
+	<-resultCh
+}
+
+func TestAutocomplete_AsyncErrorIsDisplayed(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	resultCh := make(chan string, 1)
+
+	go func() {
+		resultCh <- Autocomplete(context.Background(), AutocompleteOptions{
+			Message: "Pick:",
+			SuggestAsync: func(_ context.Context, _ string) ([]Suggestion, error) {
+				return nil, errors.New("index unavailable")
+			},
+			Input:  in,
+			Output: out,
+		})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	in.EmitKeypress("x", Key{Name: "x"})
+	time.Sleep(10 * time.Millisecond)
+
+	frames := out.GetFrames()
+	if !strings.Contains(frames[len(frames)-1], "index unavailable") {
+		t.Fatalf("expected error in suggestions area, got %q", frames[len(frames)-1])
+	}
+
+	in.EmitKeypress("", Key{Name: "return"})
+
+	if got := <-resultCh; got != "x" {
+		t.Fatalf("expected 'x', got %q", got)
+	}
+}
+
+func TestAutocomplete_AsyncErrorIsRetried(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	var (
+		mu    sync.Mutex
+		calls []string
+	)
+
+	resultCh := make(chan string, 1)
+
+	go func() {
+		resultCh <- Autocomplete(context.Background(), AutocompleteOptions{
+			Message: "Pick:",
+			SuggestAsync: func(_ context.Context, input string) ([]Suggestion, error) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				calls = append(calls, input)
+				if len(calls) == 1 {
+					return nil, errors.New("index unavailable")
+				}
+
+				return []Suggestion{{Value: "xylophone", Hint: "instrument"}}, nil
+			},
+			Input:  in,
+			Output: out,
+		})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	in.EmitKeypress("x", Key{Name: "x"})
+	time.Sleep(10 * time.Millisecond)
+
+	// A key that leaves the input unchanged asks for the same query again
+	in.EmitKeypress("", Key{Name: "right"})
+	time.Sleep(10 * time.Millisecond)
+
+	mu.Lock()
+	gotCalls := slices.Clone(calls)
+	mu.Unlock()
+
+	if !slices.Equal(gotCalls, []string{"x", "x"}) {
+		t.Errorf("expected the failed query to be retried, got %v", gotCalls)
+	}
+
+	frames := out.GetFrames()
+	if last := removeANSI(frames[len(frames)-1]); !strings.Contains(last, "xylophone instrument") || strings.Contains(last, "index unavailable") {
+		t.Errorf("expected the retried suggestions with their hint, got %q", last)
+	}
+
+	in.EmitKeypress("\t", Key{Name: "tab"})
+	in.EmitKeypress("", Key{Name: "return"})
+
+	if got := <-resultCh; got != "xylophone" {
+		t.Fatalf("expected 'xylophone', got %q", got)
+	}
+}
+
+func TestAutocomplete_RichSuggestionsInsertValueAndShowHint(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	branches := []Suggestion{
+		{Value: "feature/login", Hint: "3 days ago"},
+		{Value: "main", Label: "main (default)", Hint: "today"},
+	}
+
+	resultCh := make(chan string, 1)
+
+	go func() {
+		resultCh <- Autocomplete(context.Background(), AutocompleteOptions{
+			Message: "Branch:",
+			SuggestItems: func(input string) []Suggestion {
+				return FuzzyMatch(input, branches)
+			},
+			Input:  in,
+			Output: out,
+		})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	in.EmitKeypress("m", Key{Name: "m"})
+	in.EmitKeypress("a", Key{Name: "a"})
+	time.Sleep(10 * time.Millisecond)
+
+	frames := out.GetFrames()
+	last := frames[len(frames)-1]
+
+	if plain := removeANSI(last); !strings.Contains(plain, "main (default) today") {
+		t.Errorf("expected label followed by hint, got %q", plain)
+	}
+
+	if !strings.Contains(last, bold(cyan("ma"))) {
+		t.Errorf("expected matched characters highlighted, got %q", last)
+	}
+
+	in.EmitKeypress("", Key{Name: "down"})
+	in.EmitKeypress("\t", Key{Name: "tab"})
+	time.Sleep(10 * time.Millisecond)
+	in.EmitKeypress("", Key{Name: "return"})
+
+	if got := <-resultCh; got != "main" {
+		t.Fatalf("expected value 'main' to be inserted, got %q", got)
+	}
+}
+
+func TestAutocomplete_TabCompletesLongestCommonPrefix(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	resultCh := make(chan string, 1)
+
+	go func() {
+		resultCh <- Autocomplete(context.Background(), AutocompleteOptions{
+			Message: "Branch:",
+			Suggest: suggestFn([]string{"feature/login", "feature/logout", "fix/typo"}),
+			Input:   in,
+			Output:  out,
+		})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	in.EmitKeypress("f", Key{Name: "f"})
+	in.EmitKeypress("e", Key{Name: "e"})
+	in.EmitKeypress("\t", Key{Name: "tab"})
+	time.Sleep(10 * time.Millisecond)
+	in.EmitKeypress("", Key{Name: "return"})
+
+	if got := <-resultCh; got != "feature/log" {
+		t.Fatalf("expected completion to common prefix 'feature/log', got %q", got)
+	}
+}
+
+func TestFuzzyMatch_RanksBestFirst(t *testing.T) {
+	items := []Suggestion{
+		{Value: "release/foo-bar"},
+		{Value: "fb"},
+		{Value: "feature/bar"},
+		{Value: "docs"},
+	}
+
+	got := FuzzyMatch("fb", items)
+
+	var values []string
+	for _, it := range got {
+		values = append(values, it.Value)
+	}
+
+	if !slices.Equal(values, []string{"fb", "feature/bar", "release/foo-bar"}) {
+		t.Errorf("unexpected ranking %v", values)
+	}
+}
+
+func TestPrefixMatch(t *testing.T) {
+	items := []Suggestion{{Value: "Go"}, {Value: "golang"}, {Value: "rust"}}
+
+	got := PrefixMatch("go", items)
+	if len(got) != 2 || got[0].Value != "Go" || got[1].Value != "golang" {
+		t.Errorf("unexpected prefix matches %v", got)
+	}
+}
+
+func TestFuzzyPositions(t *testing.T) {
+	tests := []struct {
+		query, target string
+		want          []int
+	}{
+		{"log", "feature/login", []int{8, 9, 10}},
+		{"fl", "feature/login", []int{0, 8}},
+		{"xyz", "feature/login", nil},
+		{"", "anything", nil},
+	}
+
+	for _, tt := range tests {
+		if got := fuzzyPositions(tt.query, tt.target); !slices.Equal(got, tt.want) {
+			t.Errorf("fuzzyPositions(%q, %q) = %v, want %v", tt.query, tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestAutocomplete_PasteRefreshesSuggestions(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	resultCh := make(chan string, 1)
+
+	go func() {
+		resultCh <- Autocomplete(context.Background(), AutocompleteOptions{
+			Message: "Package name:",
+			Suggest: suggestFn([]string{"github.com/yarlson/tap", "golang.org/x/term"}),
+			Input:   in,
+			Output:  out,
+		})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	in.EmitPaste("yarl\n")
+	time.Sleep(5 * time.Millisecond)
+
+	frames := out.GetFrames()
+	if last := removeANSI(frames[len(frames)-1]); !strings.Contains(last, "github.com/yarlson/tap") || strings.Contains(last, "golang.org") {
+		t.Errorf("expected suggestions filtered by pasted text, got %q", last)
+	}
+
+	in.EmitKeypress("", Key{Name: "tab"})
+	in.EmitKeypress("", Key{Name: "return"})
+
+	if got := <-resultCh; got != "github.com/yarlson/tap" {
+		t.Fatalf("expected accepted suggestion, got %q", got)
+	}
+}
@@ -19,7 +19,7 @@ const (
 
 type BoxOptions struct {
 	Output         Writer
-	Columns        int          // terminal columns; if 0, default to 80
+	Columns        int          // terminal columns; if 0, the Output's width or 80
 	WidthFraction  float64      // 0..1 fraction of Columns; ignored if WidthAuto
 	WidthAuto      bool         // compute width to content automatically (capped by Columns)
 	TitlePadding   int          // spaces padding inside borders around title
@@ -29,6 +29,7 @@ type BoxOptions struct {
 	Rounded        bool
 	IncludePrefix  bool
 	FormatBorder   func(string) string // formatter for border glyphs (e.g., color)
+	Theme          *Theme              // overrides the global theme
 }
 
 func defaultBorderFormat(s string) string { return s }
@@ -52,11 +53,15 @@ func Box(message, title string, opts BoxOptions) {
 		return
 	}
 
+	out = withColorProfile(out)
+
 	columns := opts.Columns
 	if columns <= 0 {
-		columns = 80
+		columns = getColumns(out)
 	}
 
+	th := resolveTheme(opts.Theme)
+
 	formatBorder := opts.FormatBorder
 	if formatBorder == nil {
 		formatBorder = defaultBorderFormat
@@ -70,24 +75,24 @@ func Box(message, title string, opts BoxOptions) {
 
 	linePrefix := ""
 	if opts.IncludePrefix {
-		linePrefix = gray(Bar) + " "
+		linePrefix = th.muted(th.Bar) + " "
 	}
 
 	var symbols [4]string
 	if opts.Rounded {
-		symbols[0] = formatBorder(CornerTopLeft)
-		symbols[1] = formatBorder(CornerTopRight)
-		symbols[2] = formatBorder(CornerBottomLeft)
-		symbols[3] = formatBorder(CornerBottomRight)
+		symbols[0] = formatBorder(th.CornerTopLeft)
+		symbols[1] = formatBorder(th.CornerTopRight)
+		symbols[2] = formatBorder(th.CornerBottomLeft)
+		symbols[3] = formatBorder(th.CornerBottomRight)
 	} else {
-		symbols[0] = formatBorder(BarStart)
-		symbols[1] = formatBorder(BarStartRight)
-		symbols[2] = formatBorder(BarEnd)
-		symbols[3] = formatBorder(BarEndRight)
+		symbols[0] = formatBorder(th.BarStart)
+		symbols[1] = formatBorder(th.BarStartRight)
+		symbols[2] = formatBorder(th.BarEnd)
+		symbols[3] = formatBorder(th.BarEndRight)
 	}
 
-	hSymbol := formatBorder(BarH)
-	vSymbol := formatBorder(Bar)
+	hSymbol := formatBorder(th.BarH)
+	vSymbol := formatBorder(th.Bar)
 
 	maxBoxWidth := columns - visibleWidth(linePrefix)
 
@@ -146,3 +146,13 @@ func TestTruncateToWidth_PreservesColorSafety(t *testing.T) {
 	assert.Contains(t, truncated, Green)
 	assert.True(t, strings.HasSuffix(truncated, Reset))
 }
+
+func TestBox_DefaultsToWriterWidth(t *testing.T) {
+	out := NewMockWritable()
+	out.SetSize(30, 0)
+
+	Box("Hello world", "", BoxOptions{Output: out, WidthFraction: 1.0})
+
+	lines := strings.Split(removeANSI(strings.Join(out.GetFrames(), "")), "\n")
+	assert.Equal(t, 30, visibleWidth(lines[0]))
+}
@@ -0,0 +1,333 @@
+package tap
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	xterm "golang.org/x/term"
+)
+
+// ColorProfile describes how many colors a Writer can display.
+type ColorProfile int
+
+const (
+	ColorNone      ColorProfile = iota // no colors or text attributes
+	Color16                            // the 16 basic ANSI colors
+	Color256                           // the xterm 256-color palette
+	ColorTrueColor                     // 24-bit RGB
+)
+
+// ColorProfiler is implemented by Writers that know their own color
+// capability. It takes precedence over detection.
+type ColorProfiler interface {
+	ColorProfile() ColorProfile
+}
+
+// fdWriter is implemented by Writers backed by a file descriptor.
+type fdWriter interface {
+	Fd() uintptr
+}
+
+// DetectColorProfile reports the color profile for w. A Writer implementing
+// ColorProfiler decides for itself; otherwise FORCE_COLOR and NO_COLOR are
+// honored, output that is not a terminal gets no colors, and TERM/COLORTERM
+// select the palette.
+func DetectColorProfile(w Writer) ColorProfile {
+	if cp, ok := w.(ColorProfiler); ok {
+		return cp.ColorProfile()
+	}
+
+	if force, ok := os.LookupEnv("FORCE_COLOR"); ok {
+		switch strings.ToLower(force) {
+		case "0", "false":
+			return ColorNone
+		case "2":
+			return Color256
+		case "3":
+			return ColorTrueColor
+		default:
+			return max(envColorProfile(), Color16)
+		}
+	}
+
+	if os.Getenv("NO_COLOR") != "" {
+		return ColorNone
+	}
+
+	f, ok := w.(fdWriter)
+	if !ok || !xterm.IsTerminal(int(f.Fd())) {
+		return ColorNone
+	}
+
+	return envColorProfile()
+}
+
+// envColorProfile picks a profile from TERM and COLORTERM.
+func envColorProfile() ColorProfile {
+	term := os.Getenv("TERM")
+	colorTerm := strings.ToLower(os.Getenv("COLORTERM"))
+
+	switch {
+	case term == "dumb":
+		return ColorNone
+	case colorTerm == "truecolor" || colorTerm == "24bit":
+		return ColorTrueColor
+	case strings.Contains(term, "256color"):
+		return Color256
+	case os.Getenv("WT_SESSION") != "":
+		// Windows Terminal supports 24-bit color without setting COLORTERM
+		return ColorTrueColor
+	default:
+		return Color16
+	}
+}
+
+// profileWriter converts SGR color sequences written through it to a profile.
+type profileWriter struct {
+	Writer
+	profile ColorProfile
+}
+
+// sgrRegexp matches SGR (Select Graphic Rendition) sequences.
+var sgrRegexp = regexp.MustCompile("\x1b\\[[0-9;:]*m")
+
+// withColorProfile wraps w so that colors degrade to its detected profile.
+// Writers that support true color are returned unchanged.
+func withColorProfile(w Writer) Writer {
+	if w == nil {
+		return nil
+	}
+
+	if _, ok := w.(*profileWriter); ok {
+		return w
+	}
+
+	profile := DetectColorProfile(w)
+	if profile == ColorTrueColor {
+		return w
+	}
+
+	return &profileWriter{Writer: w, profile: profile}
+}
+
+func (w *profileWriter) Write(p []byte) (int, error) {
+	converted := sgrRegexp.ReplaceAllStringFunc(string(p), func(seq string) string {
+		return convertSGR(seq, w.profile)
+	})
+
+	if _, err := w.Writer.Write([]byte(converted)); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// ColorProfile reports the profile the writer converts to.
+func (w *profileWriter) ColorProfile() ColorProfile { return w.profile }
+
+// Size reports the size of the wrapped writer's terminal.
+func (w *profileWriter) Size() (cols, rows int) { return terminalSize(w.Writer) }
+
+// Fd returns the wrapped writer's file descriptor so terminal checks see
+// through the wrapper. Writers without one get an invalid descriptor, which
+// is never a terminal.
+func (w *profileWriter) Fd() uintptr {
+	if f, ok := w.Writer.(fdWriter); ok {
+		return f.Fd()
+	}
+
+	return ^uintptr(0)
+}
+
+// OnWithOff forwards to the wrapped Writer, so handlers can be unregistered.
+func (w *profileWriter) OnWithOff(event string, handler func()) func() {
+	return onWithOff(w.Writer, event, handler)
+}
+
+// convertSGR rewrites the color parameters of an SGR sequence for profile,
+// keeping text attributes. Sequences left empty are dropped, and ColorNone
+// drops every sequence, so plain output carries no escape codes at all.
+func convertSGR(seq string, profile ColorProfile) string {
+	if profile == ColorNone {
+		return ""
+	}
+
+	body := seq[2 : len(seq)-1]
+	if body == "" || profile == ColorTrueColor {
+		return seq
+	}
+
+	fields := strings.FieldsFunc(body, func(r rune) bool { return r == ';' || r == ':' })
+	params := make([]int, 0, len(fields))
+
+	for _, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return seq
+		}
+
+		params = append(params, n)
+	}
+
+	var out []string
+
+	for i := 0; i < len(params); i++ {
+		n := params[i]
+
+		switch {
+		case (n == 38 || n == 48) && i+1 < len(params):
+			bg := n == 48
+
+			var color int // 256-palette index, or -1 for 24-bit
+
+			r, g, b := 0, 0, 0
+
+			switch {
+			case params[i+1] == 5 && i+2 < len(params):
+				color = params[i+2]
+				i += 2
+			case params[i+1] == 2 && i+4 < len(params):
+				color = -1
+				r, g, b = params[i+2], params[i+3], params[i+4]
+				i += 4
+			default:
+				return seq
+			}
+
+			if code := degradeColor(color, r, g, b, bg, profile); code != "" {
+				out = append(out, code)
+			}
+
+		case (n >= 30 && n <= 37) || (n >= 90 && n <= 97) || (n >= 40 && n <= 47) || (n >= 100 && n <= 107) || n == 39 || n == 49:
+			out = append(out, strconv.Itoa(n))
+
+		default:
+			out = append(out, strconv.Itoa(n))
+		}
+	}
+
+	if len(out) == 0 {
+		return ""
+	}
+
+	return "\x1b[" + strings.Join(out, ";") + "m"
+}
+
+// degradeColor converts a 256-palette index (or an RGB value when index is
+// -1) to the SGR parameters for profile.
+func degradeColor(index, r, g, b int, bg bool, profile ColorProfile) string {
+	base := 38
+	if bg {
+		base = 48
+	}
+
+	switch profile {
+	case ColorNone:
+		return ""
+	case Color256:
+		if index < 0 {
+			index = rgbTo256(r, g, b)
+		}
+
+		return strconv.Itoa(base) + ";5;" + strconv.Itoa(index)
+	}
+
+	if index >= 0 {
+		r, g, b = paletteRGB(index)
+	}
+
+	c := nearestANSI16(r, g, b)
+
+	code := 30 + c
+	if c >= 8 {
+		code = 90 + c - 8
+	}
+
+	if bg {
+		code += 10
+	}
+
+	return strconv.Itoa(code)
+}
+
+// ansi16 holds the typical RGB values of the 16 basic ANSI colors.
+var ansi16 = [16][3]int{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// cubeLevels are the channel values of the 6x6x6 color cube.
+var cubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+
+// paletteRGB returns the RGB value of a 256-palette index.
+func paletteRGB(index int) (r, g, b int) {
+	switch {
+	case index < 16:
+		c := ansi16[max(index, 0)]
+		return c[0], c[1], c[2]
+	case index < 232:
+		i := index - 16
+		return cubeLevels[i/36], cubeLevels[i/6%6], cubeLevels[i%6]
+	default:
+		v := 8 + (min(index, 255)-232)*10
+		return v, v, v
+	}
+}
+
+// rgbTo256 returns the closest 256-palette index, choosing between the color
+// cube and the grayscale ramp.
+func rgbTo256(r, g, b int) int {
+	level := func(v int) int {
+		best := 0
+		for i, l := range cubeLevels {
+			if abs(v-l) < abs(v-cubeLevels[best]) {
+				best = i
+			}
+		}
+
+		return best
+	}
+
+	ri, gi, bi := level(r), level(g), level(b)
+	cube := 16 + 36*ri + 6*gi + bi
+	cubeDist := colorDistance(r, g, b, cubeLevels[ri], cubeLevels[gi], cubeLevels[bi])
+
+	gray := min(max((r+g+b)/3-8, 0)/10, 23)
+	v := 8 + gray*10
+
+	if colorDistance(r, g, b, v, v, v) < cubeDist {
+		return 232 + gray
+	}
+
+	return cube
+}
+
+// nearestANSI16 returns the index of the closest basic ANSI color.
+func nearestANSI16(r, g, b int) int {
+	best, bestDist := 0, -1
+
+	for i, c := range ansi16 {
+		if d := colorDistance(r, g, b, c[0], c[1], c[2]); bestDist < 0 || d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+
+	return best
+}
+
+func colorDistance(r1, g1, b1, r2, g2, b2 int) int {
+	dr, dg, db := r1-r2, g1-g2, b1-b2
+	return dr*dr + dg*dg + db*db
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
@@ -0,0 +1,184 @@
+package tap
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// plainWriter is a Writer with no file descriptor and no reported profile.
+type plainWriter struct{ bytes.Buffer }
+
+func (w *plainWriter) On(string, func()) {}
+func (w *plainWriter) Emit(string)       {}
+
+func TestConvertSGR(t *testing.T) {
+	tests := []struct {
+		name    string
+		seq     string
+		profile ColorProfile
+		want    string
+	}{
+		{"reset dropped", Reset, ColorNone, ""},
+		{"basic color dropped", Cyan, ColorNone, ""},
+		{"attributes dropped", Inverse, ColorNone, ""},
+		{"mixed dropped", "\x1b[1;96m", ColorNone, ""},
+		{"attributes kept in 16", "\x1b[1;96m", Color16, "\x1b[1;96m"},
+		{"basic color kept in 16", Cyan, Color16, Cyan},
+		{"truecolor to 256", "\x1b[38;2;255;0;0m", Color256, "\x1b[38;5;196m"},
+		{"truecolor to 16", "\x1b[38;2;255;0;0m", Color16, "\x1b[91m"},
+		{"truecolor background to 16", "\x1b[48;2;0;0;0m", Color16, "\x1b[40m"},
+		{"256 gray to 256 unchanged", "\x1b[38;5;244m", Color256, "\x1b[38;5;244m"},
+		{"256 to 16", "\x1b[38;5;46m", Color16, "\x1b[92m"},
+		{"colon separators", "\x1b[38:2:0:0:0m", Color16, "\x1b[30m"},
+		{"truecolor untouched", "\x1b[38;2;1;2;3m", ColorTrueColor, "\x1b[38;2;1;2;3m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := convertSGR(tt.seq, tt.profile); got != tt.want {
+				t.Errorf("convertSGR(%q) = %q, want %q", tt.seq, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRGBTo256(t *testing.T) {
+	if got := rgbTo256(128, 128, 128); got != 244 {
+		t.Errorf("expected gray ramp index 244, got %d", got)
+	}
+
+	if got := rgbTo256(0, 0, 255); got != 21 {
+		t.Errorf("expected cube index 21, got %d", got)
+	}
+}
+
+func TestDetectColorProfile(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("TERM", "xterm-256color")
+	t.Setenv("COLORTERM", "")
+
+	w := &plainWriter{}
+
+	// Not a terminal
+	if got := DetectColorProfile(w); got != ColorNone {
+		t.Errorf("expected no colors for non-terminal output, got %v", got)
+	}
+
+	t.Setenv("FORCE_COLOR", "1")
+
+	if got := DetectColorProfile(w); got != Color256 {
+		t.Errorf("expected FORCE_COLOR to enable TERM's palette, got %v", got)
+	}
+
+	t.Setenv("FORCE_COLOR", "3")
+
+	if got := DetectColorProfile(w); got != ColorTrueColor {
+		t.Errorf("expected FORCE_COLOR=3 to force true color, got %v", got)
+	}
+
+	t.Setenv("FORCE_COLOR", "0")
+
+	if got := DetectColorProfile(w); got != ColorNone {
+		t.Errorf("expected FORCE_COLOR=0 to disable colors, got %v", got)
+	}
+
+	// A Writer reporting its own profile wins over the environment
+	mock := NewMockWritable()
+	mock.SetColorProfile(Color16)
+
+	if got := DetectColorProfile(mock); got != Color16 {
+		t.Errorf("expected reported profile, got %v", got)
+	}
+}
+
+func TestDetectColorProfile_NoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	if got := DetectColorProfile(&plainWriter{}); got != ColorNone {
+		t.Errorf("expected NO_COLOR to disable colors, got %v", got)
+	}
+}
+
+func TestWithColorProfile_StripsColorsFromPrompt(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+	out.SetColorProfile(ColorNone)
+
+	done := make(chan string, 1)
+
+	go func() {
+		done <- Text(context.Background(), TextOptions{
+			Message: "Name:",
+			Input:   in,
+			Output:  out,
+		})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	in.EmitKeypress("a", Key{Name: "a"})
+	in.EmitKeypress("", Key{Name: "return"})
+	<-done
+
+	all := strings.Join(out.GetFrames(), "")
+	for _, color := range []string{Cyan, Gray, Green} {
+		if strings.Contains(all, color) {
+			t.Errorf("expected %q to be stripped, got %q", color, all)
+		}
+	}
+
+	if sgrRegexp.MatchString(all) {
+		t.Errorf("expected no SGR sequences, got %q", all)
+	}
+}
+
+func TestMessage_NonTerminalOutputHasNoEscapes(t *testing.T) {
+	var w plainWriter
+
+	Message("Saved", MessageOptions{Output: &w, Hint: "to disk"})
+	Outro("Done", MessageOptions{Output: &w})
+
+	if got := w.String(); strings.Contains(got, "\x1b") {
+		t.Errorf("expected no escape sequences, got %q", got)
+	}
+}
+
+func TestWithColorProfile_DegradesUtilities(t *testing.T) {
+	out := NewMockWritable()
+	out.SetColorProfile(Color256)
+
+	theme := DefaultTheme()
+	theme.SuccessColor = "\x1b[38;2;255;0;0m"
+
+	Message("done", MessageOptions{Output: out, Theme: theme})
+
+	if all := strings.Join(out.GetFrames(), ""); !strings.Contains(all, "\x1b[38;5;196m") {
+		t.Errorf("expected true color to degrade to 256 colors, got %q", all)
+	}
+}
+
+func TestProfileWriter_ForwardsFd(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	w := &profileWriter{Writer: &fileWriter{file: f}, profile: Color16}
+	if got := w.Fd(); got != f.Fd() {
+		t.Errorf("expected the wrapped descriptor %d, got %d", f.Fd(), got)
+	}
+
+	// Writers without a descriptor are never taken for a terminal
+	plain := &profileWriter{Writer: &plainWriter{}, profile: Color16}
+	if supportsSynchronizedOutput(plain) {
+		t.Error("expected a writer without a descriptor not to be a terminal")
+	}
+
+	if cols, rows := terminalSize(&profileWriter{Writer: &fileWriter{file: f}}); cols != 0 || rows != 0 {
+		t.Errorf("expected no size for a regular file, got %dx%d", cols, rows)
+	}
+}
@@ -1,6 +1,9 @@
 package tap
 
-import "context"
+import (
+	"context"
+	"fmt"
+)
 
 // Confirm creates a styled confirm prompt.
 func Confirm(ctx context.Context, opts ConfirmOptions) bool {
@@ -23,14 +26,16 @@ func Confirm(ctx context.Context, opts ConfirmOptions) bool {
 
 // confirm implements the core confirm prompt logic.
 func confirm(ctx context.Context, opts ConfirmOptions) bool {
+	th := resolveTheme(opts.Theme)
+
 	active := opts.Active
 	if active == "" {
-		active = "Yes"
+		active = resolveLocale(opts.Locale).Yes
 	}
 
 	inactive := opts.Inactive
 	if inactive == "" {
-		inactive = "No"
+		inactive = resolveLocale(opts.Locale).No
 	}
 
 	initial := opts.InitialValue
@@ -43,7 +48,7 @@ func confirm(ctx context.Context, opts ConfirmOptions) bool {
 			s := p.StateSnapshot()
 
 			// Create title with symbol and message
-			title := gray(Bar) + "\n" + Symbol(s) + "  " + opts.Message + "\n"
+			title := th.muted(th.Bar) + "\n" + th.Symbol(s) + "  " + opts.Message + "\n"
 
 			// If we're submitting, show simplified version
 			if s == StateSubmit {
@@ -57,19 +62,27 @@ func confirm(ctx context.Context, opts ConfirmOptions) bool {
 					}
 				}
 
-				return title + gray(Bar) + "  " + dim(value)
+				return title + th.muted(th.Bar) + "  " + dim(value)
 			}
 
 			var activeOption, inactiveOption string
 			if currentValue {
-				activeOption = green(RadioActive) + " " + active
-				inactiveOption = dim(RadioInactive) + " " + dim(inactive)
+				activeOption = th.success(th.RadioActive) + " " + active
+				inactiveOption = dim(th.RadioInactive) + " " + dim(inactive)
 			} else {
-				activeOption = dim(RadioInactive) + " " + dim(active)
-				inactiveOption = green(RadioActive) + " " + inactive
+				activeOption = dim(th.RadioInactive) + " " + dim(active)
+				inactiveOption = th.success(th.RadioActive) + " " + inactive
+			}
+
+			return title + th.active(th.Bar) + "  " + activeOption + " " + dim("/") + " " + inactiveOption + "\n" + th.active(th.BarEnd)
+		},
+		Announce: func(_ *Prompt) string {
+			format := resolveLocale(opts.Locale).SelectedAnnouncement
+			if currentValue {
+				return fmt.Sprintf(format, active, 1, 2)
 			}
 
-			return title + cyan(Bar) + "  " + activeOption + " " + dim("/") + " " + inactiveOption + "\n" + cyan(BarEnd)
+			return fmt.Sprintf(format, inactive, 2, 2)
 		},
 	})
 
@@ -0,0 +1,143 @@
+package tap
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	xterm "golang.org/x/term"
+
+	"github.com/yarlson/tap/internal/terminal"
+)
+
+// Openers for the controlling terminal; tests replace them with plain files.
+var (
+	openTTYInput  = terminal.OpenTTYInput
+	openTTYOutput = terminal.OpenTTY
+)
+
+// suspender is implemented by readers backed by a real terminal that can hand
+// the TTY over to another program and take it back afterwards.
+type suspender interface {
+	Suspend() (resume func() error, err error)
+}
+
+// editorCommand returns the command used to edit a file: the explicit
+// override, then $VISUAL, then $EDITOR, falling back to vi.
+func editorCommand(override string) []string {
+	for _, cmd := range []string{override, os.Getenv("VISUAL"), os.Getenv("EDITOR")} {
+		if fields := strings.Fields(cmd); len(fields) > 0 {
+			return fields
+		}
+	}
+
+	return []string{"vi"}
+}
+
+// editorStdio returns the files an editor runs on. It draws on out when that
+// is a terminal, then on the output target, then on the controlling terminal,
+// so a full-screen editor reaches the user even when stdout is captured. It
+// reads from stdin, or from the controlling terminal when stdin is redirected.
+// release closes the files opened here.
+func editorStdio(out Writer) (stdin, stdout *os.File, release func()) {
+	var opened []*os.File
+
+	release = func() {
+		for _, f := range opened {
+			_ = f.Close()
+		}
+	}
+
+	stdin, stdout = os.Stdin, os.Stdout
+
+	if !isTerminalFile(stdin) {
+		if f, err := openTTYInput(); err == nil {
+			stdin = f
+			opened = append(opened, f)
+		}
+	}
+
+	if fw, ok := out.(*fileWriter); ok && isTerminalFile(fw.file) {
+		return stdin, fw.file, release
+	}
+
+	if f := terminal.Output(); isTerminalFile(f) {
+		return stdin, f, release
+	}
+
+	if f, err := openTTYOutput(); err == nil {
+		stdout = f
+		opened = append(opened, f)
+	}
+
+	return stdin, stdout, release
+}
+
+// isTerminalFile reports whether f is a terminal.
+func isTerminalFile(f *os.File) bool {
+	return f != nil && xterm.IsTerminal(int(f.Fd()))
+}
+
+// openInEditor writes content to a temp file, runs the editor on it with the
+// terminal suspended, and returns the edited content. A single trailing
+// newline added by the editor is dropped.
+func openInEditor(command, content string, in Reader, out Writer) (string, error) {
+	f, err := os.CreateTemp("", "tap-*.txt")
+	if err != nil {
+		return "", fmt.Errorf("create temp file: %w", err)
+	}
+
+	name := f.Name()
+	defer func() { _ = os.Remove(name) }()
+
+	if _, err := f.WriteString(content); err != nil {
+		_ = f.Close()
+		return "", fmt.Errorf("write temp file: %w", err)
+	}
+
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("write temp file: %w", err)
+	}
+
+	if out != nil {
+		_, _ = out.Write([]byte(CursorShow + bracketedPasteDisable))
+	}
+
+	var resume func() error
+	if s, ok := in.(suspender); ok {
+		if resume, err = s.Suspend(); err != nil {
+			return "", fmt.Errorf("suspend terminal: %w", err)
+		}
+	}
+
+	stdin, stdout, release := editorStdio(out)
+	defer release()
+
+	args := editorCommand(command)
+	cmd := exec.Command(args[0], append(args[1:], name)...) //nolint:gosec // editor command is user-configured
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = stdin, stdout, stdout
+	runErr := cmd.Run()
+
+	if resume != nil {
+		_ = resume()
+	}
+
+	if out != nil {
+		_, _ = out.Write([]byte(CursorHide + bracketedPasteEnable))
+	}
+
+	if runErr != nil {
+		return "", fmt.Errorf("editor %s: %w", args[0], runErr)
+	}
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return "", fmt.Errorf("read temp file: %w", err)
+	}
+
+	edited := strings.TrimSuffix(string(data), "\n")
+	edited = strings.TrimSuffix(edited, "\r")
+
+	return edited, nil
+}
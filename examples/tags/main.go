@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"github.com/yarlson/tap"
+)
+
+func main() {
+	tap.Intro("🏷️  Tags Example")
+
+	labels := tap.Tags(context.Background(), tap.TagsOptions{
+		Message:     "Labels:",
+		Placeholder: "Type a label, Enter or comma to add",
+		Suggest: func(input string) []string {
+			var out []string
+
+			for _, l := range []string{"bug", "feature", "docs", "frontend", "backend", "needs-triage"} {
+				if strings.Contains(l, strings.ToLower(input)) {
+					out = append(out, l)
+				}
+			}
+
+			return out
+		},
+	})
+
+	recipients := tap.Tags(context.Background(), tap.TagsOptions{
+		Message: "Notify:",
+		Validate: func(s string) error {
+			if _, err := mail.ParseAddress(s); err != nil {
+				return fmt.Errorf("not a valid email address")
+			}
+
+			return nil
+		},
+	})
+
+	tap.Message(fmt.Sprintf("Labels: %s", strings.Join(labels, ", ")))
+	tap.Outro(fmt.Sprintf("Notifying %d recipient(s)", len(recipients)))
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yarlson/tap"
+)
+
+func main() {
+	// Brand colors and ASCII-friendly glyphs, applied to everything below
+	theme := tap.DefaultTheme()
+	theme.StepActive = "›"
+	theme.StepSubmit = "✔"
+	theme.ActiveColor = "\033[38;2;255;95;0m"
+	theme.SuccessColor = "\033[38;2;0;200;120m"
+	tap.SetTheme(theme)
+
+	tap.Intro("🎨 Theme Example")
+
+	name := tap.Text(context.Background(), tap.TextOptions{
+		Message:     "Project name:",
+		Placeholder: "my-app",
+	})
+
+	spin := tap.NewSpinner(tap.SpinnerOptions{})
+	spin.Start("Scaffolding")
+	time.Sleep(time.Second)
+	spin.Stop("Scaffolded", 0)
+
+	// A single prompt can still use its own theme
+	plain := tap.DefaultTheme()
+	plain.ActiveColor = ""
+
+	deploy := tap.Confirm(context.Background(), tap.ConfirmOptions{
+		Message: "Deploy now?",
+		Theme:   plain,
+	})
+
+	tap.Outro(fmt.Sprintf("Created %s (deploy: %v)", name, deploy))
+}
@@ -0,0 +1,85 @@
+package terminal
+
+import (
+	"slices"
+	"sync"
+)
+
+// The restore registry records how to undo every terminal mode that is
+// currently enabled. A mode registers its undo when it is enabled and
+// releases it when it is disabled normally; Restore runs whatever is left.
+// Modes that can be turned back on also record a redo, used when the
+// process continues after a stop.
+type mode struct {
+	undo, redo func()
+}
+
+var (
+	restoreMu  sync.Mutex
+	restoreSeq uint64
+	restoreFns = map[uint64]mode{}
+)
+
+// Track registers undo to run on Restore and returns a function that
+// unregisters it, for when the mode has been disabled normally.
+func Track(undo func()) (release func()) {
+	return TrackMode(undo, nil)
+}
+
+// TrackMode is like Track for a mode that redo turns back on after the
+// process is stopped and continued.
+func TrackMode(undo, redo func()) (release func()) {
+	restoreMu.Lock()
+	defer restoreMu.Unlock()
+
+	restoreSeq++
+	id := restoreSeq
+	restoreFns[id] = mode{undo: undo, redo: redo}
+
+	return func() {
+		restoreMu.Lock()
+		defer restoreMu.Unlock()
+
+		delete(restoreFns, id)
+	}
+}
+
+// Restore undoes every registered mode, newest first, and clears the
+// registry. It is safe to call more than once.
+func Restore() {
+	restoreMu.Lock()
+	modes := sortedModes(restoreFns)
+	restoreFns = map[uint64]mode{}
+	restoreMu.Unlock()
+
+	// Newest first, so modes enabled on top of others are undone before them
+	for _, m := range slices.Backward(modes) {
+		m.undo()
+	}
+}
+
+// redoableModes returns the registered modes that can be turned back on,
+// oldest first.
+func redoableModes() []mode {
+	restoreMu.Lock()
+	defer restoreMu.Unlock()
+
+	return slices.DeleteFunc(sortedModes(restoreFns), func(m mode) bool { return m.redo == nil })
+}
+
+// sortedModes returns the modes in registration order.
+func sortedModes(fns map[uint64]mode) []mode {
+	ids := make([]uint64, 0, len(fns))
+	for id := range fns {
+		ids = append(ids, id)
+	}
+
+	slices.Sort(ids)
+
+	modes := make([]mode, len(ids))
+	for i, id := range ids {
+		modes[i] = fns[id]
+	}
+
+	return modes
+}
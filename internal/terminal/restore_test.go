@@ -0,0 +1,49 @@
+package terminal
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRestore_UndoesNewestFirst(t *testing.T) {
+	var undone []string
+
+	Track(func() { undone = append(undone, "first") })
+	release := Track(func() { undone = append(undone, "released") })
+	Track(func() { undone = append(undone, "last") })
+
+	release()
+	Restore()
+
+	if want := []string{"last", "first"}; !slices.Equal(undone, want) {
+		t.Errorf("expected %v, got %v", want, undone)
+	}
+
+	Restore()
+
+	if len(undone) != 2 {
+		t.Errorf("expected a second Restore to do nothing, got %v", undone)
+	}
+}
+
+func TestRedoableModes(t *testing.T) {
+	var log []string
+
+	releaseFirst := TrackMode(func() {}, func() { log = append(log, "first") })
+	releasePlain := Track(func() {})
+	releaseLast := TrackMode(func() {}, func() { log = append(log, "last") })
+
+	defer func() {
+		releaseFirst()
+		releasePlain()
+		releaseLast()
+	}()
+
+	for _, m := range redoableModes() {
+		m.redo()
+	}
+
+	if want := []string{"first", "last"}; !slices.Equal(log, want) {
+		t.Errorf("expected %v, got %v", want, log)
+	}
+}
@@ -0,0 +1,77 @@
+package terminal
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+)
+
+var (
+	signalMu      sync.Mutex
+	signalCh      chan os.Signal // non-nil while SIGINT and SIGTERM are caught
+	signalsOff    bool
+	signalHandler func(os.Signal)
+)
+
+// SetSignals configures SIGINT and SIGTERM handling. When catch is false the
+// signals are no longer caught and are left to the app. Otherwise each is
+// passed to handler or, when handler is nil, the terminal is restored and
+// the process exits with status 1.
+func SetSignals(catch bool, handler func(os.Signal)) {
+	signalMu.Lock()
+	signalsOff = !catch
+	signalHandler = handler
+
+	if !catch && signalCh != nil {
+		signal.Stop(signalCh)
+		close(signalCh)
+		signalCh = nil
+	}
+
+	signalMu.Unlock()
+
+	terminalMu.Lock()
+	open := globalTerminal != nil
+	terminalMu.Unlock()
+
+	if catch && open {
+		catchSignals()
+	}
+}
+
+// catchSignals starts catching SIGINT and SIGTERM unless they are left to
+// the app or already caught.
+func catchSignals() {
+	signalMu.Lock()
+	defer signalMu.Unlock()
+
+	if signalsOff || signalCh != nil {
+		return
+	}
+
+	ch := setupTermSignal()
+	signalCh = ch
+
+	go func() {
+		for sig := range ch {
+			handleSignal(sig)
+		}
+	}()
+}
+
+// handleSignal passes sig to the handler, or restores the terminal and
+// exits when there is none.
+func handleSignal(sig os.Signal) {
+	signalMu.Lock()
+	h := signalHandler
+	signalMu.Unlock()
+
+	if h != nil {
+		h(sig)
+		return
+	}
+
+	Restore()
+	printControl("\n")
+	os.Exit(1)
+}
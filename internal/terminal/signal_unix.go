@@ -5,7 +5,9 @@ package terminal
 import (
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
+	"time"
 )
 
 // setupResizeSignal sets up SIGWINCH handling for terminal resize on Unix systems.
@@ -16,6 +18,71 @@ func setupResizeSignal() chan os.Signal {
 	return sigChan
 }
 
+// jobControl reports whether the process can be stopped and continued.
+const jobControl = true
+
+// setupContinueSignal sets up SIGCONT handling for redrawing after a stop.
+func setupContinueSignal() chan os.Signal {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGCONT)
+
+	return sigChan
+}
+
+var (
+	stopMu sync.Mutex
+	stopCh chan os.Signal // receives SIGTSTP once setupStopSignal ran
+)
+
+// setupStopSignal sets up SIGTSTP handling. go-tty leaves ISIG on in raw
+// mode, so Ctrl+Z usually arrives as this signal rather than as a key.
+func setupStopSignal() chan os.Signal {
+	stopMu.Lock()
+	defer stopMu.Unlock()
+
+	stopCh = make(chan os.Signal, 1)
+	signal.Notify(stopCh, syscall.SIGTSTP)
+
+	return stopCh
+}
+
+// stopTimeout bounds the wait for SIGCONT. The kernel discards SIGTSTP for
+// a process group orphaned by its shell, so no SIGCONT would ever come.
+const stopTimeout = 500 * time.Millisecond
+
+// stopSelf stops the process with SIGTSTP, or its whole process group as
+// the terminal does for Ctrl+Z when pid is 0, and returns once the process
+// is continued, or after stopTimeout when the stop did not take effect.
+func stopSelf(pid int) {
+	cont := make(chan os.Signal, 1)
+	signal.Notify(cont, syscall.SIGCONT)
+
+	defer signal.Stop(cont)
+
+	// SIGTSTP takes its default action, stopping the process, rather than
+	// being caught again
+	stopMu.Lock()
+	signal.Reset(syscall.SIGTSTP)
+	stopMu.Unlock()
+
+	defer func() {
+		stopMu.Lock()
+		if stopCh != nil {
+			signal.Notify(stopCh, syscall.SIGTSTP)
+		}
+		stopMu.Unlock()
+	}()
+
+	if syscall.Kill(pid, syscall.SIGTSTP) != nil {
+		return
+	}
+
+	select {
+	case <-cont:
+	case <-time.After(stopTimeout):
+	}
+}
+
 // setupTermSignal sets up SIGTERM handling for clean shutdown on Unix systems.
 func setupTermSignal() chan os.Signal {
 	sigChan := make(chan os.Signal, 1)
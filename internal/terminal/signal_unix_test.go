@@ -0,0 +1,74 @@
+//go:build !windows
+
+package terminal
+
+import (
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestSetSignals_PassesSignalsToHandler(t *testing.T) {
+	got := make(chan os.Signal, 1)
+
+	SetSignals(true, func(sig os.Signal) { got <- sig })
+	defer func() {
+		SetSignals(false, nil)
+		SetSignals(true, nil)
+	}()
+
+	catchSignals()
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGTERM); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case sig := <-got:
+		if sig != syscall.SIGTERM {
+			t.Errorf("expected SIGTERM, got %v", sig)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the handler to receive the signal")
+	}
+}
+
+func TestCatchStop_SIGTSTPHandsBackTerminal(t *testing.T) {
+	stopped := make(chan int, 1)
+	steps := make(chan string, 2)
+
+	orig := raiseStop
+	raiseStop = func(pid int) { stopped <- pid }
+
+	defer func() { raiseStop = orig }()
+
+	release := TrackMode(func() { steps <- "undo" }, func() { steps <- "redo" })
+	defer release()
+
+	catchStop()
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGTSTP); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case pid := <-stopped:
+		if pid != os.Getpid() {
+			t.Errorf("expected only this process to be stopped, got pid %d", pid)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected SIGTSTP to run the stop path")
+	}
+
+	for _, want := range []string{"undo", "redo"} {
+		select {
+		case got := <-steps:
+			if got != want {
+				t.Errorf("expected %s, got %s", want, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("expected the mode to be %s", want)
+		}
+	}
+}
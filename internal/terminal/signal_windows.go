@@ -17,6 +17,25 @@ func setupResizeSignal() chan os.Signal {
 	return make(chan os.Signal, 1)
 }
 
+// jobControl reports whether the process can be stopped and continued;
+// Windows has no job control signals.
+const jobControl = false
+
+// setupContinueSignal returns a channel that never receives, as Windows
+// processes are not stopped and continued.
+func setupContinueSignal() chan os.Signal {
+	return make(chan os.Signal, 1)
+}
+
+// setupStopSignal returns a channel that never receives, as Windows has no
+// SIGTSTP.
+func setupStopSignal() chan os.Signal {
+	return make(chan os.Signal, 1)
+}
+
+// stopSelf is never called on Windows.
+func stopSelf(int) {}
+
 // setupTermSignal sets up interrupt handling for clean shutdown on Windows.
 func setupTermSignal() chan os.Signal {
 	sigChan := make(chan os.Signal, 1)
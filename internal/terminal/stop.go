@@ -0,0 +1,94 @@
+package terminal
+
+import (
+	"os"
+	"sync"
+)
+
+// StopProcess stops the process the way a shell stops a job on Ctrl+Z, for
+// input in raw mode where the key does not raise SIGTSTP itself. It undoes
+// the registered modes and hands the TTY back with suspend, stops with
+// SIGTSTP, and once the process is continued (SIGCONT) takes the TTY back
+// and turns the modes on again. It does nothing where job control is not
+// supported.
+func StopProcess(suspend func() (resume func() error, err error)) error {
+	return stopProcess(suspend, 0)
+}
+
+// raiseStop stops the process or, for pid 0, its process group, and returns
+// once it continues. Tests replace it.
+var raiseStop = stopSelf
+
+// stopProcess is StopProcess stopping pid, or the process group for 0.
+func stopProcess(suspend func() (resume func() error, err error), pid int) error {
+	if !jobControl {
+		return nil
+	}
+
+	modes := redoableModes()
+	for i := len(modes) - 1; i >= 0; i-- {
+		modes[i].undo()
+	}
+
+	resume, err := suspend()
+	if err == nil {
+		raiseStop(pid)
+		err = resume()
+	}
+
+	for _, m := range modes {
+		m.redo()
+	}
+
+	return err
+}
+
+// OnContinue registers a callback run when the process continues after
+// being stopped, so output can be drawn again below whatever the shell
+// printed meanwhile. It returns a func that unregisters the callback.
+func OnContinue(handler func()) (remove func()) {
+	return continueHandler.add(handler, setupContinueSignal)
+}
+
+var continueHandler = &signalHandlers{}
+
+var catchStopOnce sync.Once
+
+// catchStop routes SIGTSTP, sent by the terminal for Ctrl+Z or by kill,
+// through StopProcess, so the terminal modes are undone before the process
+// stops and turned on again when it continues.
+func catchStop() {
+	catchStopOnce.Do(func() {
+		ch := setupStopSignal()
+
+		go func() {
+			for range ch {
+				handleStop()
+			}
+		}()
+	})
+}
+
+// handleStop stops the process for a caught SIGTSTP. The terminal is handed
+// back first unless that already happened.
+func handleStop() {
+	terminalMu.Lock()
+	t := globalTerminal
+	idle := idleResume != nil
+	terminalMu.Unlock()
+
+	suspend := func() (func() error, error) {
+		if t != nil && !idle {
+			if resume, err := t.Suspend(); err == nil {
+				return resume, nil
+			}
+		}
+
+		// Idle, or handed to another program such as $EDITOR: the terminal
+		// is in its original mode already
+		return func() error { return nil }, nil
+	}
+
+	// Only this process: the terminal sent the signal to the whole group
+	_ = stopProcess(suspend, os.Getpid())
+}
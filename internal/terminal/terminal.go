@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"os"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/mattn/go-tty"
+	xterm "golang.org/x/term"
 )
 
 // ANSI escape sequences for terminal control.
@@ -20,8 +23,24 @@ const (
 	SaveCursor = "\x1b[s"
 	RestCursor = "\x1b[u"
 
+	// Alternate screen buffer: entering saves the cursor and shows a blank
+	// screen; leaving restores the original screen and cursor.
+	AltScreenEnter = "\x1b[?1049h"
+	AltScreenExit  = "\x1b[?1049l"
+
+	// SGR mouse reporting: button presses and the wheel (1000) reported with
+	// decimal coordinates (1006), which work at any terminal size.
+	MouseEnable  = "\x1b[?1000h\x1b[?1006h"
+	MouseDisable = "\x1b[?1006l\x1b[?1000l"
+
+	// RequestPosition asks the terminal to report the cursor position, which
+	// arrives as a "position" key. Call ExpectPosition when writing it.
+	RequestPosition = "\x1b[6n"
+
 	// xterm modifyOtherKeys level 2: report modified keys (e.g. Shift+Enter).
 	enableModifyOtherKeys = "\x1b[>4;2m"
+	// Reset modifyOtherKeys to the terminal default.
+	disableModifyOtherKeys = "\x1b[>4m"
 )
 
 // MoveUp returns ANSI sequence to move cursor up n lines.
@@ -41,6 +60,7 @@ type Key struct {
 	Ctrl    bool   // True if Ctrl modifier was pressed
 	Shift   bool   // True if Shift modifier was pressed
 	Content string // Paste content when Name == "paste"
+	X, Y    int    // 1-based column and row for "click", "wheelup", "wheeldown", "mouse" and "position"
 }
 
 // Terminal manages terminal I/O operations with channel-based key input.
@@ -50,26 +70,92 @@ type Terminal struct {
 	keys      chan Key
 	done      chan struct{}
 	closeOnce sync.Once
+	freeOnce  sync.Once // guards Close
 	Reader    *Reader
 	Writer    *Writer
+
+	origState *xterm.State  // TTY mode before tty.Open; nil when it could not be read
+	suspendMu sync.Mutex    // protects suspended
+	suspended chan struct{} // non-nil while Suspend is in effect; closed on resume
+	parked    chan struct{} // readKeys acknowledges it stopped reading
 }
 
 // Reader provides read-only access to the key channel.
 type Reader struct {
 	keys   <-chan Key
+	term   *Terminal     // owning terminal, for Suspend
 	cancel chan struct{} // Cancel channel to stop current consumer
 	mu     sync.Mutex    // Protects cancel channel
 }
 
-// Writer wraps stdout.
+// Writer wraps the output file, stdout unless changed with SetOutput.
 type Writer struct{}
 
+var (
+	outputMu sync.RWMutex
+	output   = os.Stdout
+)
+
+// SetOutput directs all terminal output, including the control sequences
+// written on setup and shutdown, to f.
+func SetOutput(f *os.File) {
+	outputMu.Lock()
+	defer outputMu.Unlock()
+
+	output = f
+}
+
+// Output returns the file terminal output is written to.
+func Output() *os.File {
+	outputMu.RLock()
+	defer outputMu.RUnlock()
+
+	return output
+}
+
+// OpenTTY opens the controlling terminal for writing, so output reaches the
+// user even when stdout and stderr are redirected.
+func OpenTTY() (*os.File, error) {
+	name := "/dev/tty"
+	if runtime.GOOS == "windows" {
+		name = "CONOUT$"
+	}
+
+	return os.OpenFile(name, os.O_WRONLY, 0)
+}
+
+// OpenTTYInput opens the controlling terminal for reading, so a child
+// process can take input from the user even when stdin is redirected.
+func OpenTTYInput() (*os.File, error) {
+	name := "/dev/tty"
+	if runtime.GOOS == "windows" {
+		name = "CONIN$"
+	}
+
+	return os.Open(name)
+}
+
+// printControl writes terminal control sequences to the output when it is a
+// terminal; redirected output would only collect them as garbage.
+func printControl(a ...any) {
+	out := Output()
+	if xterm.IsTerminal(int(out.Fd())) {
+		_, _ = fmt.Fprint(out, a...)
+	}
+}
+
 // Singleton terminal management to prevent multiple terminals competing for input.
 // When multiple prompts run sequentially, they should share a single TTY reader
 // to avoid the race condition where old readKeys goroutines steal keypresses.
 var (
 	globalTerminal *Terminal
 	terminalMu     sync.Mutex
+
+	// users counts the Terminals returned by New and not yet closed. When it
+	// drops to zero the shared terminal goes idle: it is suspended, and
+	// idleResume takes it back on the next New.
+	users      int
+	idleResume func() error
 )
 
 // New creates a new terminal instance and starts key reading.
@@ -92,9 +178,25 @@ func New() (*Terminal, error) {
 			closeOnce: sync.Once{}, // Fresh once for this wrapper
 		}
 
+		users++
+
+		if resume := idleResume; resume != nil {
+			idleResume = nil
+			_ = resume()
+		}
+
 		return term, nil
 	}
 
+	// Remember the TTY's original mode so Suspend can hand a cooked terminal
+	// to child processes such as $EDITOR. It is read from the TTY itself,
+	// which is what raw mode applies to, even when stdin is redirected.
+	var origState *xterm.State
+	if f, err := OpenTTYInput(); err == nil {
+		origState, _ = xterm.GetState(int(f.Fd()))
+		_ = f.Close()
+	}
+
 	// First terminal - create new TTY
 	t, err := tty.Open()
 	if err != nil {
@@ -105,28 +207,29 @@ func New() (*Terminal, error) {
 	doneChan := make(chan struct{})
 
 	term := &Terminal{
-		tty:      t,
-		readRune: t.ReadRune,
-		keys:     keysChan,
-		done:     doneChan,
-		Reader:   &Reader{keys: keysChan},
-		Writer:   &Writer{},
+		tty:       t,
+		readRune:  t.ReadRune,
+		keys:      keysChan,
+		done:      doneChan,
+		Writer:    &Writer{},
+		origState: origState,
+		parked:    make(chan struct{}, 1),
 	}
+	term.Reader = &Reader{keys: keysChan, term: term}
 
 	globalTerminal = term
+	users = 1
 
 	// Request modified-key reporting for terminals that support xterm modifyOtherKeys.
 	// This enables escape sequences for keys like Shift+Enter.
-	fmt.Print(enableModifyOtherKeys)
+	printControl(enableModifyOtherKeys)
 
-	// Set up signal handling for clean shutdown
-	sigChan := setupTermSignal()
+	// Raw mode and modifyOtherKeys last until the terminal is closed
+	Track(term.close)
 
-	go func() {
-		<-sigChan
-		fmt.Print(CursorShow, "\n")
-		os.Exit(1)
-	}()
+	// Set up signal handling for clean shutdown, and for stops
+	catchSignals()
+	catchStop()
 
 	// Start key reading goroutine
 	go term.readKeys()
@@ -137,6 +240,49 @@ func New() (*Terminal, error) {
 	return term, nil
 }
 
+// Close gives the terminal back. When every Terminal returned by New is
+// closed, the TTY leaves raw mode and modifyOtherKeys is turned off, so the
+// shell gets its terminal back even if Restore is never called. The next New
+// takes it over again.
+func (t *Terminal) Close() {
+	t.freeOnce.Do(func() {
+		terminalMu.Lock()
+		defer terminalMu.Unlock()
+
+		// A terminal closed by Restore has nothing left to give back.
+		if globalTerminal == nil || globalTerminal.done != t.done {
+			return
+		}
+
+		users--
+		if users > 0 || idleResume != nil {
+			return
+		}
+
+		if resume, err := globalTerminal.Suspend(); err == nil {
+			idleResume = resume
+		}
+	})
+}
+
+// close stops key reading, turns modifyOtherKeys off and takes the TTY out
+// of raw mode. The next New opens the terminal again.
+func (t *Terminal) close() {
+	t.closeOnce.Do(func() {
+		terminalMu.Lock()
+		if globalTerminal == t {
+			globalTerminal = nil
+			users = 0
+			idleResume = nil
+		}
+		terminalMu.Unlock()
+
+		printControl(disableModifyOtherKeys)
+		close(t.done)
+		_ = t.tty.Close()
+	})
+}
+
 // readKeys continuously reads from TTY and sends parsed keys to channel.
 func (t *Terminal) readKeys() {
 	defer func() {
@@ -152,6 +298,7 @@ func (t *Terminal) readKeys() {
 
 		r, err := t.readRune()
 		if err != nil {
+			t.waitWhileSuspended()
 			continue
 		}
 
@@ -164,6 +311,99 @@ func (t *Terminal) readKeys() {
 	}
 }
 
+// waitWhileSuspended blocks the reader while the terminal is suspended so it
+// does not steal input from the program that owns the TTY.
+func (t *Terminal) waitWhileSuspended() {
+	t.suspendMu.Lock()
+	ch := t.suspended
+	t.suspendMu.Unlock()
+
+	if ch == nil {
+		return
+	}
+
+	select {
+	case t.parked <- struct{}{}:
+	default:
+	}
+
+	select {
+	case <-ch:
+	case <-t.done:
+	}
+}
+
+// Suspend stops key reading and restores the terminal's original mode so
+// another program (e.g. $EDITOR) can use it. The returned function re-enters
+// raw mode and resumes key reading.
+func (t *Terminal) Suspend() (resume func() error, err error) {
+	if t.tty == nil {
+		return func() error { return nil }, nil
+	}
+
+	t.suspendMu.Lock()
+	if t.suspended != nil {
+		t.suspendMu.Unlock()
+		return nil, fmt.Errorf("terminal already suspended")
+	}
+
+	ch := make(chan struct{})
+	t.suspended = ch
+	t.suspendMu.Unlock()
+
+	// Drop a stale acknowledgement left by an earlier timed-out Suspend.
+	select {
+	case <-t.parked:
+	default:
+	}
+
+	// Interrupt the blocked read; readKeys parks until resume. Terminals whose
+	// input cannot take deadlines fall back to best effort.
+	in := t.tty.Input()
+	if in.SetReadDeadline(time.Now()) == nil {
+		select {
+		case <-t.parked:
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	printControl(disableModifyOtherKeys)
+
+	fd := int(in.Fd())
+
+	var rawState *xterm.State
+	if t.origState != nil {
+		rawState, _ = xterm.GetState(fd)
+		_ = xterm.Restore(fd, t.origState)
+	}
+
+	return func() error {
+		var err error
+		if rawState != nil {
+			err = xterm.Restore(fd, rawState)
+		}
+
+		printControl(enableModifyOtherKeys)
+		_ = in.SetReadDeadline(time.Time{})
+
+		t.suspendMu.Lock()
+		t.suspended = nil
+		t.suspendMu.Unlock()
+		close(ch)
+
+		return err
+	}, nil
+}
+
+// Suspend hands the TTY to another program; see Terminal.Suspend.
+func (r *Reader) Suspend() (resume func() error, err error) {
+	if r.term == nil {
+		return func() error { return nil }, nil
+	}
+
+	return r.term.Suspend()
+}
+
 // parseKey converts a rune to a Key struct, handling escape sequences.
 func (t *Terminal) parseKey(r rune) Key {
 	switch r {
@@ -192,6 +432,11 @@ func (t *Terminal) parseKey(r rune) Key {
 			return Key{Name: string(r), Rune: r}
 		}
 
+		// Remaining C0 controls are Ctrl+letter (Ctrl+A = 1 ... Ctrl+Z = 26)
+		if r >= 1 && r <= 26 {
+			return Key{Name: string('a' + r - 1), Ctrl: true}
+		}
+
 		return Key{Name: "", Rune: r}
 	}
 }
@@ -210,13 +455,15 @@ func (t *Terminal) resolveEscapePrefix(n1 rune) Key {
 }
 
 // parseCSI parses a CSI (Control Sequence Introducer) sequence after ESC[.
-// Handles: arrow keys, delete, kitty keyboard protocol, xterm modifyOtherKeys.
+// Handles: arrow keys, delete, kitty keyboard protocol, xterm modifyOtherKeys,
+// SGR mouse reports and cursor position reports.
 // Supports both semicolon (;) and colon (:) as parameter separators for compatibility.
 func (t *Terminal) parseCSI() Key {
 	// Collect numeric parameters and terminator
 	var params []int
 	current := 0
 	hasDigit := false
+	mouse := false // ESC[< introduces an SGR mouse report
 
 	for {
 		ch, err := t.readRune()
@@ -235,12 +482,19 @@ func (t *Terminal) parseCSI() Key {
 			current = 0
 			hasDigit = false
 
+		case ch == '<' && len(params) == 0 && !hasDigit:
+			mouse = true
+
 		default:
 			// Terminator character reached
 			if hasDigit {
 				params = append(params, current)
 			}
 
+			if mouse {
+				return resolveMouse(params, ch)
+			}
+
 			return t.resolveCSI(params, ch)
 		}
 	}
@@ -261,7 +515,21 @@ func (t *Terminal) resolveCSI(params []int, terminator rune) Key {
 		return Key{Name: "home"}
 	case 'F':
 		return Key{Name: "end"}
+	case 'Z':
+		// ESC[Z → Shift+Tab (back tab)
+		return Key{Name: "tab", Shift: true}
+	case 'R':
+		// ESC[row;colR → cursor position report, sent in reply to RequestPosition.
+		// It reads the same as a modified F3, so it only counts as a report
+		// while one is expected.
+		if len(params) == 2 && positions.take() {
+			return Key{Name: "position", X: params[1], Y: params[0]}
+		}
 
+		return functionKey(3, params)
+	case 'P', 'Q', 'S':
+		// ESC[1;modifierP..S → modified F1, F2 and F4
+		return functionKey(int(terminator-'P')+1, params)
 	case '~':
 		if len(params) == 0 {
 			return Key{Name: "escape"}
@@ -313,6 +581,86 @@ func (t *Terminal) resolveCSI(params []int, terminator rune) Key {
 	return Key{Name: "escape"}
 }
 
+// functionKey returns F1 to F4 as sent with modifiers, ESC[1;modifier
+// followed by P, Q, R or S.
+func functionKey(n int, params []int) Key {
+	if len(params) != 2 || params[0] != 1 {
+		return Key{Name: "escape"}
+	}
+
+	bits := max(params[1]-1, 0)
+
+	return Key{Name: fmt.Sprintf("f%d", n), Shift: bits&0x01 != 0, Ctrl: bits&0x04 != 0}
+}
+
+// positionRequests counts the position requests not answered yet. Requests
+// the terminal never answers, for output that is not a terminal, expire
+// after positionTimeout.
+type positionRequests struct {
+	mu      sync.Mutex
+	pending int
+	expires time.Time
+}
+
+const positionTimeout = time.Second
+
+var positions positionRequests
+
+// ExpectPosition records that RequestPosition was written, so the reply is
+// read as a "position" key rather than a modified F3.
+func ExpectPosition() {
+	positions.mu.Lock()
+	defer positions.mu.Unlock()
+
+	if time.Now().After(positions.expires) {
+		positions.pending = 0
+	}
+
+	positions.pending++
+	positions.expires = time.Now().Add(positionTimeout)
+}
+
+// take reports whether a position request is outstanding and marks it
+// answered.
+func (r *positionRequests) take() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.pending == 0 || time.Now().After(r.expires) {
+		r.pending = 0
+		return false
+	}
+
+	r.pending--
+
+	return true
+}
+
+// resolveMouse maps an SGR mouse report ESC[<button;x;y followed by M for a
+// press or m for a release to a Key. Left clicks and wheel turns get their
+// own names; other buttons, releases and motion are reported as "mouse".
+func resolveMouse(params []int, terminator rune) Key {
+	if len(params) != 3 || (terminator != 'M' && terminator != 'm') {
+		return Key{Name: "mouse"}
+	}
+
+	button, x, y := params[0], params[1], params[2]
+	key := Key{Name: "mouse", X: x, Y: y, Shift: button&4 != 0, Ctrl: button&16 != 0}
+
+	switch {
+	case terminator == 'm' || button&32 != 0:
+		// Release or motion
+	case button&64 != 0 && button&3 == 0:
+		key.Name = "wheelup"
+	case button&64 != 0 && button&3 == 1:
+		key.Name = "wheeldown"
+	case button&64 == 0 && button&3 == 0:
+		key.Name = "click"
+	}
+
+	return key
+}
+
 // resolveModifiedKey maps a keycode + modifier bitmask to a Key.
 func (t *Terminal) resolveModifiedKey(keycode, modifier int) Key {
 	// CSI modifier encoding: modifier value = 1 + bitmask
@@ -335,6 +683,10 @@ func (t *Terminal) resolveModifiedKey(keycode, modifier int) Key {
 		return Key{Name: "backspace", Shift: shift, Ctrl: ctrl}
 	case keycode == 32:
 		return Key{Name: "space", Rune: ' ', Shift: shift, Ctrl: ctrl}
+	case keycode >= 32 && keycode <= 126 && ctrl:
+		// Ctrl combinations are commands, not text: leave Rune empty so
+		// prompts don't insert the letter.
+		return Key{Name: strings.ToLower(string(rune(keycode))), Shift: shift, Ctrl: ctrl}
 	case keycode >= 32 && keycode <= 126:
 		r := rune(keycode)
 		return Key{Name: string(r), Rune: r, Shift: shift, Ctrl: ctrl}
@@ -414,7 +766,7 @@ func (t *Terminal) Keys() <-chan Key {
 
 // Write implements io.Writer.
 func (t *Terminal) Write(b []byte) (int, error) {
-	return os.Stdout.Write(b)
+	return Output().Write(b)
 }
 
 // On registers a callback for key events (compatibility adapter)
@@ -468,40 +820,71 @@ func (r *Reader) Read(_ []byte) (int, error) {
 	return 0, nil
 }
 
-type resizeHandler struct {
-	handlers []func()
+// signalHandlers holds the callbacks run for a signal.
+type signalHandlers struct {
+	handlers []*func()
+	started  bool
 	mu       sync.Mutex
 }
 
-var globalResizeHandler = &resizeHandler{}
-
-// On registers a callback for terminal events.
-func (w *Writer) On(event string, handler func()) {
-	if event != "resize" {
-		return
-	}
-
-	globalResizeHandler.mu.Lock()
-	defer globalResizeHandler.mu.Unlock()
+// add registers handler and returns a func that unregisters it. The first
+// call starts a goroutine running the handlers for every signal from setup.
+func (s *signalHandlers) add(handler func(), setup func() chan os.Signal) (remove func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	if len(globalResizeHandler.handlers) == 0 {
-		// First handler - set up signal
-		sigChan := setupResizeSignal()
+	if !s.started {
+		s.started = true
+		sigChan := setup()
 
 		go func() {
 			for range sigChan {
-				globalResizeHandler.mu.Lock()
-				handlers := append([]func(){}, globalResizeHandler.handlers...)
-				globalResizeHandler.mu.Unlock()
+				s.mu.Lock()
+				handlers := slices.Clone(s.handlers)
+				s.mu.Unlock()
 
 				for _, h := range handlers {
-					h()
+					(*h)()
 				}
 			}
 		}()
 	}
 
-	globalResizeHandler.handlers = append(globalResizeHandler.handlers, handler)
+	h := &handler
+	s.handlers = append(s.handlers, h)
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.handlers = slices.DeleteFunc(s.handlers, func(other *func()) bool { return other == h })
+	}
+}
+
+var globalResizeHandler = &signalHandlers{}
+
+// On registers a callback for terminal events: "resize" when the window
+// changes and "continue" when the process continues after a stop.
+func (w *Writer) On(event string, handler func()) {
+	_ = w.OnWithOff(event, handler)
+}
+
+// OnWithOff is like On and returns a func that unregisters the callback.
+func (w *Writer) OnWithOff(event string, handler func()) func() {
+	switch event {
+	case "resize":
+		return OnResize(handler)
+	case "continue":
+		return OnContinue(handler)
+	}
+
+	return func() {}
+}
+
+// OnResize registers a callback run when the terminal window is resized and
+// returns a func that unregisters it.
+func OnResize(handler func()) (remove func()) {
+	return globalResizeHandler.add(handler, setupResizeSignal)
 }
 
 // Emit triggers an event (no-op for compatibility).
@@ -509,5 +892,8 @@ func (w *Writer) Emit(_ string) {}
 
 // Writer methods.
 func (w *Writer) Write(b []byte) (int, error) {
-	return os.Stdout.Write(b)
+	return Output().Write(b)
 }
+
+// Fd returns the file descriptor written to, for capability detection.
+func (w *Writer) Fd() uintptr { return Output().Fd() }
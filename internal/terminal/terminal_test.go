@@ -2,6 +2,7 @@ package terminal
 
 import (
 	"io"
+	"os"
 	"testing"
 	"time"
 )
@@ -470,3 +471,237 @@ func TestResolveCSI_EndVT220(t *testing.T) {
 		t.Errorf("Name: got %q, want %q", result.Name, "end")
 	}
 }
+
+func TestResolveCSI_BackTab(t *testing.T) {
+	term := &Terminal{}
+
+	result := term.resolveCSI(nil, 'Z')
+	if result.Name != "tab" || !result.Shift {
+		t.Errorf("expected Shift+Tab, got %+v", result)
+	}
+}
+
+func TestParseKey_CtrlLetter(t *testing.T) {
+	term := &Terminal{}
+
+	// Ctrl+R arrives as the C0 control 0x12
+	result := term.parseKey(18)
+	if result.Name != "r" {
+		t.Errorf("Name: got %q, want %q", result.Name, "r")
+	}
+
+	if !result.Ctrl {
+		t.Error("Ctrl should be true for Ctrl+R")
+	}
+
+	if result.Rune != 0 {
+		t.Errorf("Rune: got %q, want 0 so the letter is not inserted", result.Rune)
+	}
+}
+
+func TestResolveModifiedKey_CtrlLetter(t *testing.T) {
+	term := &Terminal{}
+
+	// Ctrl+R via modifyOtherKeys: ESC[27;5;114~ → resolveModifiedKey(114, 5)
+	result := term.resolveModifiedKey(114, 5)
+	if result.Name != "r" || !result.Ctrl {
+		t.Errorf("got %+v, want Ctrl+r", result)
+	}
+
+	if result.Rune != 0 {
+		t.Errorf("Rune: got %q, want 0", result.Rune)
+	}
+}
+
+func TestParseKey_MouseReports(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string // after ESC
+		want  Key
+	}{
+		{"left press", "[<0;12;5M", Key{Name: "click", X: 12, Y: 5}},
+		{"left release", "[<0;12;5m", Key{Name: "mouse", X: 12, Y: 5}},
+		{"right press", "[<2;3;4M", Key{Name: "mouse", X: 3, Y: 4}},
+		{"ctrl click", "[<16;1;1M", Key{Name: "click", X: 1, Y: 1, Ctrl: true}},
+		{"drag", "[<32;7;8M", Key{Name: "mouse", X: 7, Y: 8}},
+		{"wheel up", "[<64;9;10M", Key{Name: "wheelup", X: 9, Y: 10}},
+		{"wheel down", "[<65;9;10M", Key{Name: "wheeldown", X: 9, Y: 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			term := testTerminal([]rune(tt.input)...)
+
+			if got := term.parseKey(27); got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWaitWhileSuspended_ParksUntilResume(t *testing.T) {
+	term := &Terminal{done: make(chan struct{}), parked: make(chan struct{}, 1)}
+
+	resume := make(chan struct{})
+	term.suspended = resume
+
+	returned := make(chan struct{})
+
+	go func() {
+		term.waitWhileSuspended()
+		close(returned)
+	}()
+
+	select {
+	case <-term.parked:
+	case <-time.After(time.Second):
+		t.Fatal("reader did not acknowledge suspension")
+	}
+
+	select {
+	case <-returned:
+		t.Fatal("reader resumed before resume was signalled")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(resume)
+
+	select {
+	case <-returned:
+	case <-time.After(time.Second):
+		t.Fatal("reader did not resume")
+	}
+}
+
+func TestSuspend_WithoutTTYIsNoop(t *testing.T) {
+	r := &Reader{}
+
+	resume, err := r.Suspend()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := resume(); err != nil {
+		t.Fatalf("unexpected resume error: %v", err)
+	}
+}
+
+func TestWriter_WritesToOutput(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	SetOutput(w)
+	defer SetOutput(os.Stdout)
+
+	_, _ = (&Writer{}).Write([]byte("hello"))
+	printControl(CursorShow) // not a terminal, so dropped
+
+	_ = w.Close()
+
+	b, _ := io.ReadAll(r)
+	if string(b) != "hello" {
+		t.Errorf("expected only the write on the output, got %q", b)
+	}
+}
+
+func TestSignalHandlers_Remove(t *testing.T) {
+	sig := make(chan os.Signal, 1)
+	calls := make(chan string, 2)
+
+	var handlers signalHandlers
+
+	removeA := handlers.add(func() { calls <- "a" }, func() chan os.Signal { return sig })
+	handlers.add(func() { calls <- "b" }, func() chan os.Signal {
+		t.Error("expected the signal to be set up once")
+		return nil
+	})
+
+	removeA()
+	sig <- os.Interrupt
+
+	if got := <-calls; got != "b" {
+		t.Errorf("expected only the remaining handler to run, got %q", got)
+	}
+
+	select {
+	case got := <-calls:
+		t.Errorf("expected the removed handler not to run, got %q", got)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestClose_LastUseSuspendsTerminal(t *testing.T) {
+	shared := &Terminal{done: make(chan struct{})}
+
+	terminalMu.Lock()
+	prev, prevUsers := globalTerminal, users
+	globalTerminal, users, idleResume = shared, 2, nil
+	terminalMu.Unlock()
+
+	defer func() {
+		terminalMu.Lock()
+		globalTerminal, users, idleResume = prev, prevUsers, nil
+		terminalMu.Unlock()
+	}()
+
+	first := &Terminal{done: shared.done}
+	second := &Terminal{done: shared.done}
+
+	first.Close()
+	first.Close()
+
+	if users != 1 || idleResume != nil {
+		t.Fatalf("expected one use left and no suspend, got %d uses, suspended %v", users, idleResume != nil)
+	}
+
+	second.Close()
+
+	if users != 0 || idleResume == nil {
+		t.Fatalf("expected the last Close to suspend the terminal, got %d uses, suspended %v", users, idleResume != nil)
+	}
+
+	stale := &Terminal{done: make(chan struct{})}
+	stale.Close()
+
+	if users != 0 {
+		t.Fatalf("expected a stale Close to be ignored, got %d uses", users)
+	}
+}
+
+func TestParseKey_PositionReportOnlyWhenExpected(t *testing.T) {
+	positions = positionRequests{}
+	defer func() { positions = positionRequests{} }()
+
+	if got := testTerminal([]rune("[1;2R")...).parseKey(27); got != (Key{Name: "f3", Shift: true}) {
+		t.Errorf("expected Shift+F3 without a position request, got %+v", got)
+	}
+
+	ExpectPosition()
+
+	if got := testTerminal([]rune("[24;80R")...).parseKey(27); got != (Key{Name: "position", X: 80, Y: 24}) {
+		t.Errorf("expected a position report, got %+v", got)
+	}
+
+	if got := testTerminal([]rune("[1;5R")...).parseKey(27); got != (Key{Name: "f3", Ctrl: true}) {
+		t.Errorf("expected Ctrl+F3 once the request was answered, got %+v", got)
+	}
+}
+
+func TestParseKey_ModifiedFunctionKeys(t *testing.T) {
+	tests := []struct {
+		input string // after ESC
+		want  Key
+	}{
+		{"[1;2P", Key{Name: "f1", Shift: true}},
+		{"[1;5Q", Key{Name: "f2", Ctrl: true}},
+		{"[1;6S", Key{Name: "f4", Shift: true, Ctrl: true}},
+	}
+
+	for _, tt := range tests {
+		if got := testTerminal([]rune(tt.input)...).parseKey(27); got != tt.want {
+			t.Errorf("%q: got %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+}
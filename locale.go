@@ -0,0 +1,208 @@
+package tap
+
+import (
+	"cmp"
+	"fmt"
+	"sync/atomic"
+)
+
+// PluralCategory is a CLDR plural category.
+type PluralCategory int
+
+const (
+	PluralOther PluralCategory = iota
+	PluralOne
+	PluralFew
+	PluralMany
+)
+
+// Plural holds the forms of a counter message, one per plural category.
+// Each form is a fmt format string; empty forms fall back to Other.
+type Plural struct {
+	One   string
+	Few   string
+	Many  string
+	Other string
+}
+
+// Locale is a catalog of the user-visible strings tap produces itself.
+//
+// The global locale is set with SetLocale; options structs of prompts that
+// produce built-in text also accept a *Locale that overrides it.
+type Locale struct {
+	// PluralRule picks the plural category for n. Nil uses the English rule.
+	PluralRule func(n int) PluralCategory
+
+	Yes              string // Confirm active label
+	No               string // Confirm inactive label
+	Loading          string // Autocomplete while suggestions load
+	PasswordMismatch string // Password confirmation mismatch
+	PasteLineBreaks  string // paste rejected by NewlinesReject
+	PasteTruncated   string // Text or Textarea paste cut to fit a limit
+	ContentTruncated string // Textarea editor result cut to fit a limit
+	TagExists        string // Tags duplicate; formatted with the tag
+	PastePlaceholder string // Textarea collapsed paste; formatted with the paste number
+
+	StrengthLabels [5]string // EstimatePasswordStrength labels, weakest first
+
+	// Accessible-mode announcements.
+	ErrorAnnouncement     string // formatted with the error message
+	SelectedAnnouncement  string // formatted with the label, position and count
+	CheckedAnnouncement   string // MultiSelect; formatted with the label, position and count
+	UncheckedAnnouncement string // MultiSelect; formatted with the label, position and count
+
+	SelectedCount Plural // MultiSelect counter; formatted with the count
+	SelectedOfMax Plural // MultiSelect counter with MaxItems; formatted with count and max
+	LinesCounter  Plural // Textarea MaxLines counter; formatted with lines and limit
+}
+
+// EnglishLocale returns the built-in English catalog.
+func EnglishLocale() *Locale {
+	return &Locale{
+		Yes:              "Yes",
+		No:               "No",
+		Loading:          "Loading...",
+		PasswordMismatch: "Passwords do not match",
+		PasteLineBreaks:  "Pasted text contains line breaks",
+		PasteTruncated:   "Paste truncated to fit the limit",
+		ContentTruncated: "Content truncated to fit the limit",
+		TagExists:        "%q is already added",
+		PastePlaceholder: "[Text %d]",
+
+		StrengthLabels: [5]string{"very weak", "weak", "fair", "good", "strong"},
+
+		ErrorAnnouncement:     "Error: %s",
+		SelectedAnnouncement:  "selected: %s, %d of %d",
+		CheckedAnnouncement:   "%s, checked, %d of %d",
+		UncheckedAnnouncement: "%s, not checked, %d of %d",
+
+		SelectedCount: Plural{Other: "(%d)"},
+		SelectedOfMax: Plural{Other: "(%d/%d)"},
+		LinesCounter:  Plural{One: "%d/%d line", Other: "%d/%d lines"},
+	}
+}
+
+// GermanLocale returns the bundled German catalog.
+func GermanLocale() *Locale {
+	return &Locale{
+		Yes:              "Ja",
+		No:               "Nein",
+		Loading:          "Wird geladen...",
+		PasswordMismatch: "Passwörter stimmen nicht überein",
+		PasteLineBreaks:  "Eingefügter Text enthält Zeilenumbrüche",
+		PasteTruncated:   "Eingefügter Text wurde auf das Limit gekürzt",
+		ContentTruncated: "Inhalt wurde auf das Limit gekürzt",
+		TagExists:        "%q ist bereits vorhanden",
+		PastePlaceholder: "[Text %d]",
+
+		StrengthLabels: [5]string{"sehr schwach", "schwach", "mittel", "gut", "stark"},
+
+		ErrorAnnouncement:     "Fehler: %s",
+		SelectedAnnouncement:  "ausgewählt: %s, %d von %d",
+		CheckedAnnouncement:   "%s, markiert, %d von %d",
+		UncheckedAnnouncement: "%s, nicht markiert, %d von %d",
+
+		SelectedCount: Plural{Other: "(%d ausgewählt)"},
+		SelectedOfMax: Plural{Other: "(%d/%d ausgewählt)"},
+		LinesCounter:  Plural{One: "%d/%d Zeile", Other: "%d/%d Zeilen"},
+	}
+}
+
+// RussianLocale returns the bundled Russian catalog.
+func RussianLocale() *Locale {
+	return &Locale{
+		PluralRule: slavicPluralRule,
+
+		Yes:              "Да",
+		No:               "Нет",
+		Loading:          "Загрузка...",
+		PasswordMismatch: "Пароли не совпадают",
+		PasteLineBreaks:  "Вставленный текст содержит переносы строк",
+		PasteTruncated:   "Вставленный текст обрезан до лимита",
+		ContentTruncated: "Текст обрезан до лимита",
+		TagExists:        "%q уже добавлен",
+		PastePlaceholder: "[Текст %d]",
+
+		StrengthLabels: [5]string{"очень слабый", "слабый", "средний", "хороший", "надёжный"},
+
+		ErrorAnnouncement:     "Ошибка: %s",
+		SelectedAnnouncement:  "выбрано: %s, %d из %d",
+		CheckedAnnouncement:   "%s, отмечено, %d из %d",
+		UncheckedAnnouncement: "%s, не отмечено, %d из %d",
+
+		SelectedCount: Plural{Other: "(выбрано: %d)"},
+		SelectedOfMax: Plural{Other: "(выбрано: %d/%d)"},
+		LinesCounter:  Plural{One: "%d/%d строка", Few: "%d/%d строки", Many: "%d/%d строк", Other: "%d/%d строки"},
+	}
+}
+
+// englishPluralRule is the plural rule of English, German and most Western
+// European languages.
+func englishPluralRule(n int) PluralCategory {
+	if n == 1 {
+		return PluralOne
+	}
+
+	return PluralOther
+}
+
+// slavicPluralRule is the plural rule of Russian, Ukrainian and Belarusian.
+func slavicPluralRule(n int) PluralCategory {
+	n = abs(n)
+
+	switch {
+	case n%10 == 1 && n%100 != 11:
+		return PluralOne
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+// globalLocale is the locale used when options do not set one.
+var globalLocale atomic.Pointer[Locale]
+
+func init() { globalLocale.Store(EnglishLocale()) }
+
+// SetLocale replaces the global locale. Passing nil restores English.
+func SetLocale(l *Locale) {
+	if l == nil {
+		l = EnglishLocale()
+	}
+
+	globalLocale.Store(l)
+}
+
+// CurrentLocale returns the global locale.
+func CurrentLocale() *Locale { return globalLocale.Load() }
+
+// resolveLocale returns l, or the global locale when l is nil.
+func resolveLocale(l *Locale) *Locale {
+	if l != nil {
+		return l
+	}
+
+	return globalLocale.Load()
+}
+
+// Plural formats p with args, choosing the form for n.
+func (l *Locale) Plural(p Plural, n int, args ...any) string {
+	rule := l.PluralRule
+	if rule == nil {
+		rule = englishPluralRule
+	}
+
+	format := p.Other
+
+	switch rule(n) {
+	case PluralOne:
+		format = cmp.Or(p.One, format)
+	case PluralFew:
+		format = cmp.Or(p.Few, format)
+	case PluralMany:
+		format = cmp.Or(p.Many, format)
+	}
+
+	return fmt.Sprintf(format, args...)
+}
@@ -0,0 +1,165 @@
+package tap
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLocale_Plural(t *testing.T) {
+	en := EnglishLocale()
+	ru := RussianLocale()
+
+	tests := []struct {
+		loc   *Locale
+		n     int
+		want  string
+		limit int
+	}{
+		{en, 1, "1/1 line", 1},
+		{en, 2, "2/3 lines", 3},
+		{ru, 1, "1/1 строка", 1},
+		{ru, 1, "1/3 строки", 3},
+		{ru, 1, "1/5 строк", 5},
+		{ru, 1, "1/11 строк", 11},
+		{ru, 1, "1/21 строка", 21},
+		{ru, 1, "1/24 строки", 24},
+	}
+
+	for _, tt := range tests {
+		if got := tt.loc.Plural(tt.loc.LinesCounter, tt.limit, tt.n, tt.limit); got != tt.want {
+			t.Errorf("Plural(%d/%d) = %q, want %q", tt.n, tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestLocale_PluralFallsBackToOther(t *testing.T) {
+	loc := RussianLocale()
+
+	if got := loc.Plural(Plural{Other: "%d items"}, 3, 3); got != "3 items" {
+		t.Errorf("expected Other form, got %q", got)
+	}
+}
+
+func TestLocale_ConfirmPerPrompt(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	done := make(chan bool, 1)
+
+	go func() {
+		done <- Confirm(context.Background(), ConfirmOptions{
+			Message: "Fortfahren?",
+			Locale:  GermanLocale(),
+			Input:   in,
+			Output:  out,
+		})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	in.EmitKeypress("", Key{Name: "return"})
+	<-done
+
+	got := strings.Join(out.GetFrames(), "")
+	if !strings.Contains(got, "Ja") || !strings.Contains(got, "Nein") {
+		t.Errorf("expected German labels, got %q", got)
+	}
+}
+
+func TestSetLocale_MultiSelectCounter(t *testing.T) {
+	SetLocale(GermanLocale())
+	defer SetLocale(nil)
+
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	done := make(chan []string, 1)
+
+	go func() {
+		done <- MultiSelect(context.Background(), MultiSelectOptions[string]{
+			Message: "Farben:",
+			Options: []SelectOption[string]{{Value: "rot"}, {Value: "blau"}},
+			Input:   in,
+			Output:  out,
+		})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	in.EmitKeypress(" ", Key{Name: "space"})
+	time.Sleep(5 * time.Millisecond)
+	in.EmitKeypress("", Key{Name: "return"})
+	<-done
+
+	if got := strings.Join(out.GetFrames(), ""); !strings.Contains(got, dim("(1 ausgewählt)")) {
+		t.Errorf("expected localized counter, got %q", got)
+	}
+}
+
+func TestSetLocale_NilRestoresEnglish(t *testing.T) {
+	SetLocale(RussianLocale())
+	SetLocale(nil)
+
+	if got := CurrentLocale().Yes; got != "Yes" {
+		t.Errorf("expected English locale, got %q", got)
+	}
+}
+
+func TestLocale_BundledCatalogsAreComplete(t *testing.T) {
+	for name, loc := range map[string]*Locale{"en": EnglishLocale(), "de": GermanLocale(), "ru": RussianLocale()} {
+		if loc.PastePlaceholder == "" {
+			t.Errorf("%s: PastePlaceholder is empty", name)
+		}
+
+		for i, label := range loc.StrengthLabels {
+			if label == "" {
+				t.Errorf("%s: StrengthLabels[%d] is empty", name, i)
+			}
+		}
+	}
+}
+
+func TestSetLocale_PasswordStrengthLabels(t *testing.T) {
+	SetLocale(GermanLocale())
+	defer SetLocale(nil)
+
+	if got := EstimatePasswordStrength("Tr0ub4dour&3xyz").Label; got != "stark" {
+		t.Errorf("expected German label, got %q", got)
+	}
+
+	if got := EstimatePasswordStrength("").Label; got != "sehr schwach" {
+		t.Errorf("expected German label, got %q", got)
+	}
+}
+
+func TestLocale_TextareaPastePlaceholderPerPrompt(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	done := make(chan string, 1)
+
+	go func() {
+		done <- Textarea(context.Background(), TextareaOptions{
+			Message: "Текст",
+			Locale:  RussianLocale(),
+			Input:   in,
+			Output:  out,
+		})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	in.EmitPaste("hello\nworld")
+	time.Sleep(5 * time.Millisecond)
+
+	screen := screenOf(out)
+
+	in.EmitKeypress("", Key{Name: "return"})
+
+	if got := <-done; got != "hello\nworld" {
+		t.Errorf("expected the paste to expand on submit, got %q", got)
+	}
+
+	if !strings.Contains(screen, "[Текст 1]") {
+		t.Errorf("expected a localized paste placeholder, got:\n%s", screen)
+	}
+}
@@ -9,20 +9,25 @@ import (
 type MessageOptions struct {
 	Output Writer
 	Hint   string // Optional second line displayed in gray
+	Theme  *Theme // Overrides the global theme
 }
 
 // Cancel prints a cancel-styled message (bar end + red message).
 func Cancel(message string, opts ...MessageOptions) {
 	var (
-		out  Writer
-		hint string
+		out   Writer
+		hint  string
+		theme *Theme
 	)
 
 	if len(opts) > 0 {
 		out = opts[0].Output
 		hint = opts[0].Hint
+		theme = opts[0].Theme
 	}
 
+	th := resolveTheme(theme)
+
 	if out == nil {
 		out = resolveWriter()
 	}
@@ -31,25 +36,31 @@ func Cancel(message string, opts ...MessageOptions) {
 		return
 	}
 
+	out = withColorProfile(out)
+
 	if hint != "" {
-		_, _ = fmt.Fprintf(out, "%s\n%s  %s\n   %s\n\n", gray(Bar), gray(BarEnd), red(message), gray(hint))
+		_, _ = fmt.Fprintf(out, "%s\n%s  %s\n   %s\n\n", th.muted(th.Bar), th.muted(th.BarEnd), th.danger(message), th.muted(hint))
 	} else {
-		_, _ = fmt.Fprintf(out, "%s\n%s  %s\n\n", gray(Bar), gray(BarEnd), red(message))
+		_, _ = fmt.Fprintf(out, "%s\n%s  %s\n\n", th.muted(th.Bar), th.muted(th.BarEnd), th.danger(message))
 	}
 }
 
 // Intro prints an intro title (bar start + title).
 func Intro(title string, opts ...MessageOptions) {
 	var (
-		out  Writer
-		hint string
+		out   Writer
+		hint  string
+		theme *Theme
 	)
 
 	if len(opts) > 0 {
 		out = opts[0].Output
 		hint = opts[0].Hint
+		theme = opts[0].Theme
 	}
 
+	th := resolveTheme(theme)
+
 	if out == nil {
 		out = resolveWriter()
 	}
@@ -58,25 +69,31 @@ func Intro(title string, opts ...MessageOptions) {
 		return
 	}
 
+	out = withColorProfile(out)
+
 	if hint != "" {
-		_, _ = fmt.Fprintf(out, "%s  %s\n%s  %s\n", gray(BarStart), bold(title), gray(Bar), gray(hint))
+		_, _ = fmt.Fprintf(out, "%s  %s\n%s  %s\n", th.muted(th.BarStart), bold(title), th.muted(th.Bar), th.muted(hint))
 	} else {
-		_, _ = fmt.Fprintf(out, "%s  %s\n%s\n", gray(BarStart), bold(title), gray(Bar))
+		_, _ = fmt.Fprintf(out, "%s  %s\n%s\n", th.muted(th.BarStart), bold(title), th.muted(th.Bar))
 	}
 }
 
 // Outro prints a final outro (bar line, then bar end + message).
 func Outro(message string, opts ...MessageOptions) {
 	var (
-		out  Writer
-		hint string
+		out   Writer
+		hint  string
+		theme *Theme
 	)
 
 	if len(opts) > 0 {
 		out = opts[0].Output
 		hint = opts[0].Hint
+		theme = opts[0].Theme
 	}
 
+	th := resolveTheme(theme)
+
 	if out == nil {
 		out = resolveWriter()
 	}
@@ -85,24 +102,30 @@ func Outro(message string, opts ...MessageOptions) {
 		return
 	}
 
+	out = withColorProfile(out)
+
 	if hint != "" {
-		_, _ = fmt.Fprintf(out, "%s\n%s  %s\n   %s\n\n", gray(Bar), gray(BarEnd), bold(message), gray(hint))
+		_, _ = fmt.Fprintf(out, "%s\n%s  %s\n   %s\n\n", th.muted(th.Bar), th.muted(th.BarEnd), bold(message), th.muted(hint))
 	} else {
-		_, _ = fmt.Fprintf(out, "%s\n%s  %s\n\n", gray(Bar), gray(BarEnd), bold(message))
+		_, _ = fmt.Fprintf(out, "%s\n%s  %s\n\n", th.muted(th.Bar), th.muted(th.BarEnd), bold(message))
 	}
 }
 
 func Message(message string, opts ...MessageOptions) {
 	var (
-		out  Writer
-		hint string
+		out   Writer
+		hint  string
+		theme *Theme
 	)
 
 	if len(opts) > 0 {
 		out = opts[0].Output
 		hint = opts[0].Hint
+		theme = opts[0].Theme
 	}
 
+	th := resolveTheme(theme)
+
 	if out == nil {
 		out = resolveWriter()
 	}
@@ -111,12 +134,14 @@ func Message(message string, opts ...MessageOptions) {
 		return
 	}
 
-	_, _ = fmt.Fprintf(out, "%s\n", gray(Bar))
-	_, _ = fmt.Fprintf(out, "%s  %s\n", green(StepSubmit), bold(message))
+	out = withColorProfile(out)
+
+	_, _ = fmt.Fprintf(out, "%s\n", th.muted(th.Bar))
+	_, _ = fmt.Fprintf(out, "%s  %s\n", th.success(th.StepSubmit), bold(message))
 
 	if hint != "" {
-		_, _ = fmt.Fprintf(out, "%s  %s\n", gray(Bar), gray(hint))
+		_, _ = fmt.Fprintf(out, "%s  %s\n", th.muted(th.Bar), th.muted(hint))
 	} else {
-		_, _ = fmt.Fprintf(out, "%s\n", gray(Bar))
+		_, _ = fmt.Fprintf(out, "%s\n", th.muted(th.Bar))
 	}
 }
@@ -76,16 +76,74 @@ func (m *MockReadable) EmitPaste(content string) {
 type MockWritable struct {
 	Buffer    []string
 	mutex     sync.Mutex
-	listeners map[string][]func()
+	listeners map[string][]*func()
+	profile   ColorProfile
+	sync      bool
+	cols      int
+	rows      int
 }
 
 func NewMockWritable() *MockWritable {
 	return &MockWritable{
 		Buffer:    make([]string, 0),
-		listeners: make(map[string][]func()),
+		listeners: make(map[string][]*func()),
+		profile:   ColorTrueColor,
 	}
 }
 
+// ColorProfile implements ColorProfiler. Mocks report true color unless
+// changed with SetColorProfile.
+func (m *MockWritable) ColorProfile() ColorProfile {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.profile
+}
+
+// SetColorProfile sets the profile the mock reports.
+func (m *MockWritable) SetColorProfile(p ColorProfile) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.profile = p
+}
+
+// SynchronizedOutput implements SynchronizedOutputter. Mocks report no
+// support unless changed with SetSynchronizedOutput.
+func (m *MockWritable) SynchronizedOutput() bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.sync
+}
+
+// SetSynchronizedOutput sets whether the mock reports synchronized output support.
+func (m *MockWritable) SetSynchronizedOutput(on bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.sync = on
+}
+
+// Size implements Sizer. Mocks report an unknown size, so renderers fall
+// back to 80 columns, unless changed with SetSize.
+func (m *MockWritable) Size() (cols, rows int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.cols, m.rows
+}
+
+// SetSize sets the size the mock reports and emits "resize", as a terminal
+// does when its window changes.
+func (m *MockWritable) SetSize(cols, rows int) {
+	m.mutex.Lock()
+	m.cols, m.rows = cols, rows
+	m.mutex.Unlock()
+
+	m.Emit("resize")
+}
+
 func (m *MockWritable) Write(p []byte) (int, error) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
@@ -96,10 +154,31 @@ func (m *MockWritable) Write(p []byte) (int, error) {
 }
 
 func (m *MockWritable) On(event string, handler func()) {
+	_ = m.OnWithOff(event, handler)
+}
+
+// OnWithOff implements Unsubscriber.
+func (m *MockWritable) OnWithOff(event string, handler func()) func() {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
-	m.listeners[event] = append(m.listeners[event], handler)
+	h := &handler
+	m.listeners[event] = append(m.listeners[event], h)
+
+	return func() {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+
+		m.listeners[event] = slices.DeleteFunc(m.listeners[event], func(other *func()) bool { return other == h })
+	}
+}
+
+// Listeners returns how many handlers are registered for event.
+func (m *MockWritable) Listeners(event string) int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return len(m.listeners[event])
 }
 
 func (m *MockWritable) Emit(event string) {
@@ -108,7 +187,7 @@ func (m *MockWritable) Emit(event string) {
 	m.mutex.Unlock()
 
 	for _, handler := range handlers {
-		handler()
+		(*handler)()
 	}
 }
 
@@ -0,0 +1,67 @@
+package tap
+
+import (
+	"strings"
+
+	"github.com/yarlson/tap/internal/terminal"
+)
+
+// Mouse reporting and cursor position escape sequences.
+const (
+	mouseEnable     = terminal.MouseEnable
+	mouseDisable    = terminal.MouseDisable
+	requestPosition = terminal.RequestPosition
+)
+
+// frameLine returns the index of the line of the last drawn frame shown at
+// screen row y, using the terminal's report of where the frame starts. It is
+// meant for key handlers, which run inside the event loop, and always fails
+// unless the prompt enabled mouse reporting.
+func (p *Prompt) frameLine(y int) (int, bool) {
+	if !p.opts.Mouse || p.cur == nil || p.cur.frameTop == 0 || p.cur.PrevFrame == "" {
+		return 0, false
+	}
+
+	cols := getColumns(p.output)
+
+	row := p.cur.frameTop
+	for i, line := range strings.Split(p.cur.PrevFrame, "\n") {
+		bottom := row + max(countPhysicalLines(line, cols), 1) - 1
+		if y >= row && y <= bottom {
+			return i, true
+		}
+
+		row = bottom + 1
+	}
+
+	return 0, false
+}
+
+// keepFrameOnScreen moves the known frame origin up when the frame reaches
+// past the bottom of the screen, which scrolls the screen up.
+func (p *Prompt) keepFrameOnScreen(st *promptState) {
+	rows := getRows(p.output)
+	if st.frameTop == 0 || rows == 0 {
+		return
+	}
+
+	st.frameTop = max(min(st.frameTop, rows-st.PrevFrameLines+1), 1)
+}
+
+// listItemAt returns the index of the list item shown at screen row y, for
+// a list whose items start at frame line first and show height items from
+// top.
+func listItemAt(p *Prompt, y, first, top, height int) (int, bool) {
+	line, ok := p.frameLine(y)
+	if !ok || line < first || line >= first+height {
+		return 0, false
+	}
+
+	return top + line - first, true
+}
+
+// titleLines is the number of frame lines taken by the bar and the title of
+// a prompt with message.
+func titleLines(message string) int {
+	return 2 + strings.Count(message, "\n")
+}
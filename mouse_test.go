@@ -0,0 +1,247 @@
+package tap
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// reportFrameBottom answers the prompt's position request, placing the last
+// line of the frame at screen row y.
+func reportFrameBottom(in *MockReadable, y int) {
+	time.Sleep(time.Millisecond)
+	in.EmitKeypress("", Key{Name: "position", Y: y})
+}
+
+var mouseOptions = []SelectOption[string]{
+	{Value: "a"},
+	{Value: "b"},
+	{Value: "c"},
+}
+
+func TestSelect_ClickSubmitsOption(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	done := make(chan string, 1)
+
+	go func() {
+		done <- Select(context.Background(), SelectOptions[string]{
+			Message: "Pick",
+			Options: mouseOptions,
+			Mouse:   true,
+			Input:   in,
+			Output:  out,
+		})
+	}()
+
+	// Bar, title, three options, closing bar and the empty last line end at row 20
+	reportFrameBottom(in, 20)
+	in.EmitKeypress("", Key{Name: "click", X: 6, Y: 17})
+
+	if got := <-done; got != "b" {
+		t.Errorf("expected the clicked option, got %q", got)
+	}
+
+	got := strings.Join(out.GetFrames(), "")
+	if !strings.Contains(got, mouseEnable) || !strings.Contains(got, mouseDisable) {
+		t.Errorf("expected mouse reporting to be enabled and disabled, got %q", got)
+	}
+
+	if strings.LastIndex(got, mouseEnable) > strings.LastIndex(got, mouseDisable) {
+		t.Error("expected mouse reporting to be disabled last")
+	}
+}
+
+func TestSelect_ClickOutsideOptionsIsIgnored(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	done := make(chan string, 1)
+
+	go func() {
+		done <- Select(context.Background(), SelectOptions[string]{
+			Message: "Pick",
+			Options: mouseOptions,
+			Mouse:   true,
+			Input:   in,
+			Output:  out,
+		})
+	}()
+
+	reportFrameBottom(in, 20)
+	in.EmitKeypress("", Key{Name: "click", X: 1, Y: 15}) // the title
+	in.EmitKeypress("", Key{Name: "click", X: 1, Y: 19}) // the closing bar
+	in.EmitKeypress("", Key{Name: "return"})
+
+	if got := <-done; got != "a" {
+		t.Errorf("expected the initial option, got %q", got)
+	}
+}
+
+func TestSelect_WheelMovesCursor(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	done := make(chan string, 1)
+
+	go func() {
+		done <- Select(context.Background(), SelectOptions[string]{
+			Message: "Pick",
+			Options: mouseOptions,
+			Mouse:   true,
+			Input:   in,
+			Output:  out,
+		})
+	}()
+
+	time.Sleep(time.Millisecond)
+	in.EmitKeypress("", Key{Name: "wheeldown", X: 1, Y: 1})
+	in.EmitKeypress("", Key{Name: "wheeldown", X: 1, Y: 1})
+	in.EmitKeypress("", Key{Name: "wheelup", X: 1, Y: 1})
+	in.EmitKeypress("", Key{Name: "return"})
+
+	if got := <-done; got != "b" {
+		t.Errorf("expected the wheel to move the cursor, got %q", got)
+	}
+}
+
+func TestMultiSelect_ClickTogglesOption(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	done := make(chan []string, 1)
+
+	go func() {
+		done <- MultiSelect(context.Background(), MultiSelectOptions[string]{
+			Message: "Pick",
+			Options: mouseOptions,
+			Mouse:   true,
+			Input:   in,
+			Output:  out,
+		})
+	}()
+
+	reportFrameBottom(in, 20)
+	in.EmitKeypress("", Key{Name: "click", X: 6, Y: 16})
+	in.EmitKeypress("", Key{Name: "click", X: 6, Y: 18})
+	in.EmitKeypress("", Key{Name: "click", X: 6, Y: 16})
+	in.EmitKeypress("", Key{Name: "return"})
+
+	got := <-done
+	if len(got) != 1 || got[0] != "c" {
+		t.Errorf("expected only the option clicked once, got %v", got)
+	}
+}
+
+func TestTextarea_ClickPlacesCursor(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	done := make(chan string, 1)
+
+	go func() {
+		done <- Textarea(context.Background(), TextareaOptions{
+			Message:      "Notes",
+			InitialValue: "hello\nworld",
+			Mouse:        true,
+			Input:        in,
+			Output:       out,
+		})
+	}()
+
+	// Bar, title, two rows and the closing bar end at row 10
+	reportFrameBottom(in, 10)
+	in.EmitKeypress("", Key{Name: "click", X: 6, Y: 8}) // column 2 of "hello"
+	in.EmitKeypress("X", Key{Name: "x", Rune: 'X'})
+	in.EmitKeypress("", Key{Name: "click", X: 40, Y: 9}) // past the end of "world"
+	in.EmitKeypress("!", Key{Name: "!", Rune: '!'})
+	in.EmitKeypress("", Key{Name: "return"})
+
+	if got := <-done; got != "heXllo\nworld!" {
+		t.Errorf("expected edits at the clicked positions, got %q", got)
+	}
+}
+
+func TestPrompt_MouseOffByDefault(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	done := make(chan string, 1)
+
+	go func() {
+		done <- Select(context.Background(), SelectOptions[string]{
+			Message: "Pick",
+			Options: mouseOptions,
+			Input:   in,
+			Output:  out,
+		})
+	}()
+
+	reportFrameBottom(in, 20)
+	in.EmitKeypress("", Key{Name: "click", X: 6, Y: 17})
+	in.EmitKeypress("", Key{Name: "return"})
+
+	if got := <-done; got != "a" {
+		t.Errorf("expected clicks to be ignored without Mouse, got %q", got)
+	}
+
+	if got := strings.Join(out.GetFrames(), ""); strings.Contains(got, mouseEnable) || strings.Contains(got, requestPosition) {
+		t.Errorf("expected no mouse sequences, got %q", got)
+	}
+}
+
+func TestSelect_RequestsPositionOnlyWhenFrameMayMove(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	done := make(chan string, 1)
+
+	go func() {
+		done <- Select(context.Background(), SelectOptions[string]{
+			Message: "Pick",
+			Options: mouseOptions,
+			Mouse:   true,
+			Input:   in,
+			Output:  out,
+		})
+	}()
+
+	requests := func() int {
+		time.Sleep(5 * time.Millisecond)
+		return strings.Count(strings.Join(out.GetFrames(), ""), requestPosition)
+	}
+
+	if got := requests(); got != 1 {
+		t.Fatalf("expected one request after the initial frame, got %d", got)
+	}
+
+	in.EmitKeypress("", Key{Name: "down"})
+
+	if got := requests(); got != 1 {
+		t.Fatalf("expected no request for a frame drawn in place, got %d", got)
+	}
+
+	out.Emit("resize")
+
+	if got := requests(); got != 2 {
+		t.Fatalf("expected a request after a resize, got %d", got)
+	}
+
+	out.Emit("continue")
+
+	if got := requests(); got != 3 {
+		t.Fatalf("expected a request after continuing, got %d", got)
+	}
+
+	// The reply to the last request still maps clicks after more frames
+	reportFrameBottom(in, 20)
+	in.EmitKeypress("", Key{Name: "up"})
+	time.Sleep(time.Millisecond)
+	in.EmitKeypress("", Key{Name: "click", X: 6, Y: 18})
+
+	if got := <-done; got != "c" {
+		t.Errorf("expected the click to submit c, got %q", got)
+	}
+}
@@ -9,6 +9,8 @@ import (
 
 type styledMultiSelectState[T any] struct {
 	cursor   int
+	top      int // first option shown when the list scrolls
+	height   int // number of options shown
 	options  []SelectOption[T]
 	selected map[int]bool
 	order    []int
@@ -64,11 +66,34 @@ func multiSelect[T any](ctx context.Context, opts MultiSelectOptions[T]) []T {
 	}
 
 	prompt := NewPromptWithTracking(PromptOptions{
-		Input:  opts.Input,
-		Output: opts.Output,
+		Input:     opts.Input,
+		Output:    opts.Output,
+		AltScreen: opts.AltScreen,
+		Mouse:     opts.Mouse,
 		Render: func(p *Prompt) string {
 			return renderStyledMultiSelect(p, opts, state)
 		},
+		Announce: func(_ *Prompt) string {
+			if len(state.options) == 0 {
+				return ""
+			}
+
+			option := state.options[state.cursor]
+
+			label := option.Label
+			if label == "" {
+				label = fmt.Sprintf("%v", option.Value)
+			}
+
+			loc := resolveLocale(opts.Locale)
+
+			format := loc.UncheckedAnnouncement
+			if state.selected[state.cursor] {
+				format = loc.CheckedAnnouncement
+			}
+
+			return fmt.Sprintf(format, label, state.cursor+1, len(state.options))
+		},
 	}, false)
 
 	// Initialize with any preselected items
@@ -104,50 +129,61 @@ func multiSelect[T any](ctx context.Context, opts MultiSelectOptions[T]) []T {
 		}
 	})
 
-	// Space toggles selection
+	// Space toggles selection; clicking an option moves the cursor to it and toggles it
 	prompt.On("key", func(_ string, key Key) {
-		if key.Name == "space" {
-			idx := state.cursor
-			if state.selected[idx] {
-				delete(state.selected, idx)
-
-				for i, v := range state.order {
-					if v == idx {
-						state.order = slices.Delete(state.order, i, i+1)
-						break
-					}
-				}
-			} else {
-				// Enforce MaxItems if specified
-				if opts.MaxItems != nil {
-					selCount := 0
-
-					for _, v := range state.selected {
-						if v {
-							selCount++
-						}
-					}
+		switch key.Name {
+		case "space":
+		case "click":
+			idx, ok := listItemAt(prompt, key.Y, titleLines(opts.Message), state.top, state.height)
+			if !ok {
+				return
+			}
 
-					if selCount >= *opts.MaxItems {
-						// at limit; ignore additional selection
-						return
+			state.cursor = idx
+		default:
+			return
+		}
+
+		idx := state.cursor
+		if state.selected[idx] {
+			delete(state.selected, idx)
+
+			for i, v := range state.order {
+				if v == idx {
+					state.order = slices.Delete(state.order, i, i+1)
+					break
+				}
+			}
+		} else {
+			// Enforce MaxItems if specified
+			if opts.MaxItems != nil {
+				selCount := 0
+
+				for _, v := range state.selected {
+					if v {
+						selCount++
 					}
 				}
 
-				state.selected[idx] = true
-				state.order = append(state.order, idx)
+				if selCount >= *opts.MaxItems {
+					// at limit; ignore additional selection
+					return
+				}
 			}
 
-			var cur []T
+			state.selected[idx] = true
+			state.order = append(state.order, idx)
+		}
 
-			for i, opt := range state.options {
-				if state.selected[i] {
-					cur = append(cur, opt.Value)
-				}
-			}
+		var cur []T
 
-			prompt.SetImmediateValue(cur)
+		for i, opt := range state.options {
+			if state.selected[i] {
+				cur = append(cur, opt.Value)
+			}
 		}
+
+		prompt.SetImmediateValue(cur)
 	})
 
 	v := prompt.Prompt(ctx)
@@ -159,6 +195,8 @@ func multiSelect[T any](ctx context.Context, opts MultiSelectOptions[T]) []T {
 }
 
 func renderStyledMultiSelect[T any](p *Prompt, opts MultiSelectOptions[T], st *styledMultiSelectState[T]) string {
+	th := resolveTheme(opts.Theme)
+	loc := resolveLocale(opts.Locale)
 	state := p.StateSnapshot()
 	// Build title with selection count indicator
 	count := 0
@@ -171,12 +209,12 @@ func renderStyledMultiSelect[T any](p *Prompt, opts MultiSelectOptions[T], st *s
 
 	countText := ""
 	if opts.MaxItems != nil {
-		countText = fmt.Sprintf(" %s", dim(fmt.Sprintf("(%d/%d)", count, *opts.MaxItems)))
+		countText = fmt.Sprintf(" %s", dim(loc.Plural(loc.SelectedOfMax, count, count, *opts.MaxItems)))
 	} else if count > 0 {
-		countText = fmt.Sprintf(" %s", dim(fmt.Sprintf("(%d)", count)))
+		countText = fmt.Sprintf(" %s", dim(loc.Plural(loc.SelectedCount, count, count)))
 	}
 
-	title := fmt.Sprintf("%s\n%s  %s%s\n", gray(Bar), Symbol(state), opts.Message, countText)
+	title := fmt.Sprintf("%s\n%s  %s%s\n", th.muted(th.Bar), th.Symbol(state), opts.Message, countText)
 
 	switch state {
 	case StateSubmit:
@@ -195,11 +233,18 @@ func renderStyledMultiSelect[T any](p *Prompt, opts MultiSelectOptions[T], st *s
 
 		text := strings.Join(labels, ", ")
 
-		return fmt.Sprintf("%s%s  %s", title, gray(Bar), dim(text))
+		return fmt.Sprintf("%s%s  %s", title, th.muted(th.Bar), dim(text))
 	default:
 		var lines []string
 
-		for i, option := range st.options {
+		// The title, the closing bar and the empty last line take four rows
+		top, height := listWindow(p, st.top, st.cursor, len(st.options), 4)
+		st.top, st.height = top, height
+
+		for i := top; i < top+height; i++ {
+			option := st.options[i]
+			bar := th.active(listBar(th, i, top, height, len(st.options)))
+
 			label := option.Label
 			if label == "" {
 				label = fmt.Sprintf("%v", option.Value)
@@ -207,14 +252,14 @@ func renderStyledMultiSelect[T any](p *Prompt, opts MultiSelectOptions[T], st *s
 
 			checked := st.selected[i]
 
-			box := CheckboxUnchecked
+			box := th.CheckboxUnchecked
 			if checked {
-				box = CheckboxChecked
+				box = th.CheckboxChecked
 			}
 
 			text := label
 			if i == st.cursor {
-				line := fmt.Sprintf("%s %s", green(box), text)
+				line := fmt.Sprintf("%s  %s %s", bar, th.success(box), text)
 				if option.Hint != "" {
 					line += fmt.Sprintf(" %s", dim(fmt.Sprintf("(%s)", option.Hint)))
 				}
@@ -222,17 +267,15 @@ func renderStyledMultiSelect[T any](p *Prompt, opts MultiSelectOptions[T], st *s
 				lines = append(lines, line)
 			} else {
 				if checked {
-					line := fmt.Sprintf("%s %s", green(box), dim(text))
+					line := fmt.Sprintf("%s  %s %s", bar, th.success(box), dim(text))
 					lines = append(lines, line)
 				} else {
-					line := fmt.Sprintf("%s %s", dim(box), dim(text))
+					line := fmt.Sprintf("%s  %s %s", bar, dim(box), dim(text))
 					lines = append(lines, line)
 				}
 			}
 		}
 
-		optionsText := strings.Join(lines, fmt.Sprintf("\n%s  ", cyan(Bar)))
-
-		return fmt.Sprintf("%s%s  %s\n%s\n", title, cyan(Bar), optionsText, cyan(BarEnd))
+		return fmt.Sprintf("%s%s\n%s\n", title, strings.Join(lines, "\n"), th.active(th.BarEnd))
 	}
 }
@@ -0,0 +1,62 @@
+package tap
+
+import (
+	"os"
+	"sync"
+
+	"github.com/yarlson/tap/internal/terminal"
+)
+
+// OutputTarget selects where tap renders its UI.
+type OutputTarget int
+
+const (
+	// OutputStdout renders to stdout. This is the default.
+	OutputStdout OutputTarget = iota
+	// OutputStderr renders to stderr, keeping stdout free for results that
+	// are piped or captured, as in id=$(tool create).
+	OutputStderr
+	// OutputTTY renders to the controlling terminal, even when both stdout
+	// and stderr are redirected. It falls back to stderr when there is none.
+	OutputTTY
+)
+
+var (
+	ttyOnce sync.Once
+	ttyFile *os.File
+)
+
+// SetOutputTarget renders prompts, spinners and output helpers to target
+// when they are given no Output. The default theme's ASCII fallback is
+// detected again for the new target.
+func SetOutputTarget(target OutputTarget) {
+	terminal.SetOutput(outputFile(target))
+	detectedTheme.Store(detectTheme())
+}
+
+// NewOutput returns a Writer rendering to target, for the Output of a
+// single call.
+func NewOutput(target OutputTarget) Writer {
+	return &fileWriter{file: outputFile(target)}
+}
+
+// outputFile returns the file for target. The controlling terminal is
+// opened once and kept open.
+func outputFile(target OutputTarget) *os.File {
+	switch target {
+	case OutputStderr:
+		return os.Stderr
+	case OutputTTY:
+		ttyOnce.Do(func() {
+			ttyFile, _ = terminal.OpenTTY()
+		})
+
+		if ttyFile != nil {
+			return ttyFile
+		}
+
+		return os.Stderr
+	default:
+		return os.Stdout
+	}
+}
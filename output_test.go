@@ -0,0 +1,84 @@
+package tap
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// capture replaces *f with a pipe while fn runs and returns what was written.
+func capture(t *testing.T, f **os.File, fn func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orig := *f
+	*f = w
+
+	fn()
+
+	*f = orig
+	_ = w.Close()
+
+	b, _ := io.ReadAll(r)
+
+	return string(b)
+}
+
+func TestNewOutput_Stderr(t *testing.T) {
+	var stdout string
+
+	stderr := capture(t, &os.Stderr, func() {
+		stdout = capture(t, &os.Stdout, func() {
+			Message("hello", MessageOptions{Output: NewOutput(OutputStderr)})
+		})
+	})
+
+	if !strings.Contains(stderr, "hello") {
+		t.Errorf("expected message on stderr, got %q", stderr)
+	}
+
+	if stdout != "" {
+		t.Errorf("expected nothing on stdout, got %q", stdout)
+	}
+}
+
+func TestSetOutputTarget(t *testing.T) {
+	// Restored once os.Stdout is the real stdout again.
+	defer SetOutputTarget(OutputStdout)
+
+	var stdout string
+
+	stderr := capture(t, &os.Stderr, func() {
+		stdout = capture(t, &os.Stdout, func() {
+			SetOutputTarget(OutputStderr)
+			Message("hello")
+		})
+	})
+
+	if !strings.Contains(stderr, "hello") {
+		t.Errorf("expected message on stderr, got %q", stderr)
+	}
+
+	if stdout != "" {
+		t.Errorf("expected nothing on stdout, got %q", stdout)
+	}
+}
+
+func TestSetOutputTarget_DetectsThemeAgain(t *testing.T) {
+	defer SetOutputTarget(OutputStdout)
+
+	stale := ASCIITheme()
+	detectedTheme.Store(stale)
+
+	// Neither target is a terminal here, so the default theme is detected
+	SetOutputTarget(OutputStderr)
+
+	if got := CurrentTheme(); got == stale || got.StepActive != DefaultTheme().StepActive {
+		t.Errorf("expected the theme to be detected for the new target, got %+v", got)
+	}
+}
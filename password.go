@@ -3,8 +3,12 @@ package tap
 import (
 	"context"
 	"strings"
+	"unicode"
 )
 
+// defaultPasswordMask is the glyph used to mask each typed rune.
+const defaultPasswordMask = '●'
+
 // Password creates a styled password input prompt that masks user input.
 func Password(ctx context.Context, opts PasswordOptions) string {
 	if opts.Input != nil && opts.Output != nil {
@@ -24,51 +28,134 @@ func Password(ctx context.Context, opts PasswordOptions) string {
 	})
 }
 
-// password implements the core password prompt logic.
+// password implements the core password prompt logic, including the optional
+// confirmation entry.
 func password(ctx context.Context, opts PasswordOptions) string {
+	notice := ""
+
+	for {
+		first, ok := passwordEntry(ctx, opts, opts.Message, opts.Validate, notice)
+		if !ok || opts.ConfirmMessage == "" {
+			return first
+		}
+
+		// The confirmation starts empty
+		confirmOpts := opts
+		confirmOpts.InitialValue = ""
+
+		second, ok := passwordEntry(ctx, confirmOpts, opts.ConfirmMessage, nil, "")
+		if !ok {
+			return ""
+		}
+
+		if second == first {
+			return second
+		}
+
+		// The typo may be in either entry, so both are entered again
+		notice = opts.MismatchMessage
+		if notice == "" {
+			notice = resolveLocale(opts.Locale).PasswordMismatch
+		}
+
+		opts.InitialValue = ""
+	}
+}
+
+// passwordEntry runs a single masked entry and reports whether it was submitted.
+// A non-empty notice is shown as an error until the first key.
+func passwordEntry(ctx context.Context, opts PasswordOptions, message string, validateFn func(string) error, notice string) (string, bool) {
+	th := resolveTheme(opts.Theme)
+
 	var validate func(any) error
-	if opts.Validate != nil {
+	if validateFn != nil {
 		validate = func(v any) error {
 			str, _ := v.(string)
-			return opts.Validate(str)
+			return validateFn(str)
 		}
 	}
 
+	mask := opts.Mask
+	if mask == 0 {
+		mask = th.PasswordMask
+	}
+
+	if mask == 0 {
+		mask = defaultPasswordMask
+	}
+
+	revealed := false
+
+	// display renders the current input according to reveal/silent/mask settings.
+	display := func(text string, cursor int, s ClackState) string {
+		switch {
+		case revealed:
+			return renderTextWithCursor(text, cursor, s)
+		case opts.Silent:
+			if s == StateActive || s == StateInitial {
+				return inverse(" ")
+			}
+
+			return ""
+		default:
+			return renderMaskedWithCursor(len([]rune(text)), cursor, s, mask)
+		}
+	}
+
+	// masked renders a finished value without exposing it.
+	masked := func(value string) string {
+		if opts.Silent {
+			return ""
+		}
+
+		return strings.Repeat(string(mask), len([]rune(value)))
+	}
+
 	p := NewPrompt(PromptOptions{
 		Input:            opts.Input,
 		Output:           opts.Output,
 		Validate:         validate,
 		InitialUserInput: opts.InitialValue,
 		InitialValue:     opts.DefaultValue,
+		PasteNewlines:    opts.PasteNewlines,
+		Locale:           opts.Locale,
 		Render: func(p *Prompt) string {
 			s := p.StateSnapshot()
 			userInput := p.UserInputSnapshot()
 			cursor := p.CursorSnapshot()
 
 			// Title with symbol and message
-			title := gray(Bar) + "\n" + Symbol(s) + "  " + opts.Message + "\n"
+			title := th.muted(th.Bar) + "\n" + th.Symbol(s) + "  " + message + "\n"
 
-			// Build masked display of input with cursor
-			masked := renderMaskedWithCursor(userInput, cursor, s)
+			input := display(userInput, cursor, s)
+
+			// Optional strength meter rendered between the input and the bar end
+			meter := func(bar string) string {
+				if opts.Strength == nil || userInput == "" {
+					return ""
+				}
+
+				return bar + "  " + renderStrengthMeter(th, opts.Strength(userInput)) + "\n"
+			}
 
 			switch s {
 			case StateError:
 				errMsg := p.ErrorSnapshot()
-				return title + yellow(Bar) + "  " + masked + "\n" + yellow(BarEnd) + "  " + yellow(errMsg)
+				return title + th.warning(th.Bar) + "  " + input + "\n" + meter(th.warning(th.Bar)) + th.warning(th.BarEnd) + "  " + th.warning(errMsg)
 
 			case StateSubmit:
-				// Do not show raw value; show bullets only
+				// Do not show raw value; show mask only
 				value := ""
 				if val, ok := p.ValueSnapshot().(string); ok {
 					value = val
 				}
 
 				valueText := ""
-				if value != "" {
-					valueText = "  " + dim(strings.Repeat("●", len([]rune(value))))
+				if m := masked(value); m != "" {
+					valueText = "  " + dim(m)
 				}
 
-				return title + gray(Bar) + valueText
+				return title + th.muted(th.Bar) + valueText
 
 			case StateCancel:
 				value := ""
@@ -77,46 +164,64 @@ func password(ctx context.Context, opts PasswordOptions) string {
 				}
 
 				valueText := ""
-				if strings.TrimSpace(value) != "" {
-					valueText = "  " + strikethrough(dim(strings.Repeat("●", len([]rune(value)))))
+				if m := masked(value); strings.TrimSpace(value) != "" && m != "" {
+					valueText = "  " + strikethrough(dim(m))
 				}
 
-				result := title + gray(Bar) + valueText
-				if strings.TrimSpace(value) != "" {
-					result += "\n" + gray(Bar)
+				result := title + th.muted(th.Bar) + valueText
+				if valueText != "" {
+					result += "\n" + th.muted(th.Bar)
 				}
 
 				return result
 
 			default:
-				return title + cyan(Bar) + "  " + masked + "\n" + cyan(BarEnd)
+				if notice != "" {
+					title = th.muted(th.Bar) + "\n" + th.Symbol(StateError) + "  " + message + "\n"
+					return title + th.warning(th.Bar) + "  " + input + "\n" + meter(th.warning(th.Bar)) + th.warning(th.BarEnd) + "  " + th.warning(notice)
+				}
+
+				return title + th.active(th.Bar) + "  " + input + "\n" + meter(th.active(th.Bar)) + th.active(th.BarEnd)
 			}
 		},
 	})
 
+	p.On("key", func(_ string, _ Key) {
+		notice = ""
+	})
+
 	p.On("userInput", func(input string) {
 		p.SetImmediateValue(input)
 	})
 
+	enableBracketedPaste(p, opts.Output)
+
+	if opts.AllowReveal {
+		p.On("key", func(_ string, key Key) {
+			if key.Ctrl && key.Name == "r" {
+				revealed = !revealed
+			}
+		})
+	}
+
 	v := p.Prompt(ctx)
 	if s, ok := v.(string); ok {
-		return s
+		return s, true
 	}
 
-	return ""
+	return "", false
 }
 
-// renderMaskedWithCursor renders bullets for each rune in input, and shows an inverted cursor block
-// similar to the styled text behavior.
-func renderMaskedWithCursor(text string, cursor int, state ClackState) string {
+// renderMaskedWithCursor renders a mask glyph for each of n input runes, and shows an inverted cursor block
+// similar to the styled text behavior. Only the length is needed, so callers never pass the secret itself.
+func renderMaskedWithCursor(n, cursor int, state ClackState, mask rune) string {
+	maskedRunes := []rune(strings.Repeat(string(mask), n))
+
 	if state != StateActive && state != StateInitial {
-		return strings.Repeat("●", len([]rune(text)))
+		return string(maskedRunes)
 	}
 
-	runes := []rune(text)
-
-	maskedRunes := []rune(strings.Repeat("●", len(runes)))
-	if cursor >= len(runes) {
+	if cursor >= n {
 		return string(maskedRunes) + inverse(" ")
 	}
 
@@ -126,3 +231,89 @@ func renderMaskedWithCursor(text string, cursor int, state ClackState) string {
 
 	return before + inverse(char) + after
 }
+
+// renderStrengthMeter draws a four-segment meter colored by score.
+func renderStrengthMeter(th *Theme, st PasswordStrength) string {
+	score := min(max(st.Score, 0), 4)
+
+	color := th.danger
+
+	switch {
+	case score >= 3:
+		color = th.success
+	case score == 2:
+		color = th.warning
+	}
+
+	segment := th.MeterSegment
+	if segment == "" {
+		segment = "━━"
+	}
+
+	meter := strings.Repeat(segment, score)
+	if score > 0 {
+		meter = color(meter)
+	}
+
+	meter += dim(strings.Repeat(segment, 4-score))
+	if st.Label != "" {
+		meter += " " + color(st.Label)
+	}
+
+	return meter
+}
+
+// EstimatePasswordStrength is a simple estimator for PasswordOptions.Strength
+// based on length and the variety of character classes used. Its labels
+// come from the global locale's StrengthLabels.
+func EstimatePasswordStrength(s string) PasswordStrength {
+	labels := CurrentLocale().StrengthLabels
+
+	var lower, upper, digit, other bool
+
+	for _, r := range s {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+
+	classes := 0
+
+	for _, has := range []bool{lower, upper, digit, other} {
+		if has {
+			classes++
+		}
+	}
+
+	length := len([]rune(s))
+	score := 0
+
+	if length >= 8 {
+		score++
+	}
+
+	if length >= 12 {
+		score++
+	}
+
+	if classes >= 3 {
+		score++
+	}
+
+	if classes == 4 {
+		score++
+	}
+
+	if length < 6 {
+		score = 0
+	}
+
+	return PasswordStrength{Score: score, Label: labels[score]}
+}
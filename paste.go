@@ -0,0 +1,65 @@
+package tap
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/yarlson/tap/internal/terminal"
+)
+
+// Bracketed paste mode escape sequences.
+const (
+	bracketedPasteEnable  = "\x1b[?2004h"
+	bracketedPasteDisable = "\x1b[?2004l"
+)
+
+// enableBracketedPaste turns on bracketed paste mode so pastes arrive as a
+// single "paste" key, and turns it off again when the prompt finalizes.
+func enableBracketedPaste(p *Prompt, out Writer) {
+	if out == nil {
+		return
+	}
+
+	_, _ = out.Write([]byte(bracketedPasteEnable))
+
+	release := terminal.TrackMode(
+		func() { _, _ = out.Write([]byte(bracketedPasteDisable)) },
+		func() { _, _ = out.Write([]byte(bracketedPasteEnable)) },
+	)
+
+	p.On("finalize", func() {
+		_, _ = out.Write([]byte(bracketedPasteDisable))
+		release()
+	})
+}
+
+// sanitizePaste prepares pasted content for a single-line input. Trailing
+// line breaks are dropped, embedded ones are handled according to policy, and
+// tabs become spaces; other control characters are removed.
+func sanitizePaste(content string, policy NewlinePolicy, loc *Locale) (string, error) {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	content = strings.ReplaceAll(content, "\r", "\n")
+	content = strings.TrimRight(content, "\n")
+
+	if strings.Contains(content, "\n") {
+		switch policy {
+		case NewlinesReject:
+			return "", NewValidationError(loc.PasteLineBreaks)
+		case NewlinesStrip:
+			content = strings.ReplaceAll(content, "\n", "")
+		default:
+			content = strings.ReplaceAll(content, "\n", " ")
+		}
+	}
+
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\t':
+			return ' '
+		case unicode.IsControl(r):
+			return -1
+		default:
+			return r
+		}
+	}, content), nil
+}
@@ -0,0 +1,34 @@
+package tap
+
+import "testing"
+
+func TestSanitizePaste(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		policy  NewlinePolicy
+		want    string
+		wantErr bool
+	}{
+		{"plain", "token-123", NewlinesJoin, "token-123", false},
+		{"trailing newline dropped", "token\r\n", NewlinesReject, "token", false},
+		{"join", "a\nb\r\nc", NewlinesJoin, "a b c", false},
+		{"strip", "ab\ncd", NewlinesStrip, "abcd", false},
+		{"reject", "ab\ncd", NewlinesReject, "", true},
+		{"tabs and controls", "a\tb\x07c", NewlinesJoin, "a bc", false},
+		{"unicode kept", "héllo 世界", NewlinesJoin, "héllo 世界", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sanitizePaste(tt.content, tt.policy, EnglishLocale())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,7 +3,6 @@ package tap
 import (
 	"fmt"
 	"math"
-	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -15,6 +14,7 @@ type ProgressOptions struct {
 	Max    int    // maximum value (default 100)
 	Size   int    // bar width in characters (default 40)
 	Output Writer
+	Theme  *Theme // overrides the global theme
 }
 
 // Progress represents a progress bar that wraps spinner functionality.
@@ -23,18 +23,31 @@ type Progress struct {
 	max      int
 	size     int
 	output   Writer
+	theme    *Theme
 	ticker   *time.Ticker
 	stopChan chan struct{}
-	frames   []string
+
+	offOutput func() // unregisters the resize and continue handlers
+	frames    []string
+
+	// renderMu serializes drawing, which runs on the ticker, on signal
+	// goroutines and in the caller's: it is held from reading lastFrameLines
+	// until the new frame is written.
+	renderMu sync.Mutex
 
 	// Protected by mutex
-	mu              sync.RWMutex
-	value           int
-	isActive        bool
-	previousMsg     string
-	frameIndex      int
-	lastFrameLength int
-	lastPct         int
+	mu             sync.RWMutex
+	value          int
+	isActive       bool
+	previousMsg    string
+	frameIndex     int
+	lastFrameLines int // rows of the last frame, measured at the width it was drawn
+	lastPct        int
+
+	// Accessible-mode status bookkeeping.
+	statusMsg string
+	statusPct int
+	statusAt  time.Time
 }
 
 // Progress bar character styles.
@@ -72,17 +85,51 @@ func newProgress(opts ProgressOptions) *Progress {
 		size = 40
 	}
 
-	return &Progress{
+	frames := resolveTheme(opts.Theme).SpinnerFrames
+	if len(frames) == 0 {
+		frames = DefaultTheme().SpinnerFrames
+	}
+
+	p := &Progress{
 		style:      style,
 		max:        maxVal,
 		size:       size,
 		value:      0,
-		output:     opts.Output,
+		output:     withColorProfile(opts.Output),
+		theme:      opts.Theme,
 		stopChan:   make(chan struct{}),
-		frames:     []string{"◒", "◐", "◓", "◑"},
+		frames:     frames,
 		frameIndex: 0,
 		lastPct:    -1,
 	}
+
+	return p
+}
+
+// handleResize redraws an active progress bar at the new terminal width.
+func (p *Progress) handleResize() {
+	p.mu.RLock()
+	active := p.isActive
+	msg := p.previousMsg
+	p.mu.RUnlock()
+
+	if active {
+		p.render(msg)
+	}
+}
+
+// handleContinue draws an active progress bar afresh after the process
+// continues from a stop, below whatever the shell printed meanwhile.
+func (p *Progress) handleContinue() {
+	p.renderMu.Lock()
+	defer p.renderMu.Unlock()
+
+	p.mu.Lock()
+	p.lastFrameLines = 0
+	msg := p.previousMsg
+	p.mu.Unlock()
+
+	p.draw(msg)
 }
 
 // Start begins the progress bar animation.
@@ -95,8 +142,14 @@ func (p *Progress) Start(msg string) {
 	}
 
 	p.isActive = true
+	p.statusMsg = ""
 	p.previousMsg = msg
-	p.lastFrameLength = 0 // Reset for new progress bar
+	p.lastFrameLines = 0 // Reset for new progress bar
+
+	if p.output != nil {
+		p.offOutput = onTerminalEvents(p.output, p.handleResize, p.handleContinue)
+	}
+
 	p.mu.Unlock()
 
 	// Start animation
@@ -145,7 +198,12 @@ func (p *Progress) Stop(msg string, code int, opts ...StopOptions) {
 	}
 
 	p.isActive = false
-	lastLength := p.lastFrameLength
+
+	if p.offOutput != nil {
+		p.offOutput()
+		p.offOutput = nil
+	}
+
 	p.mu.Unlock()
 
 	// Stop animation
@@ -157,22 +215,32 @@ func (p *Progress) Stop(msg string, code int, opts ...StopOptions) {
 
 	oscClear(p.output)
 
+	// Wait for a frame being drawn, then clear it
+	p.renderMu.Lock()
+	defer p.renderMu.Unlock()
+
+	p.mu.RLock()
+	lastLines := p.lastFrameLines
+	p.mu.RUnlock()
+
 	// Final render with state symbol
+	th := resolveTheme(p.theme)
+
 	var symbol string
 
 	switch code {
 	case 0:
-		symbol = green(StepSubmit)
+		symbol = th.success(th.StepSubmit)
 	case 1:
-		symbol = red(StepCancel)
+		symbol = th.danger(th.StepCancel)
 	default:
-		symbol = red(StepError)
+		symbol = th.danger(th.StepError)
 	}
 
 	if p.output != nil {
-		// Clear the current progress frame (3 lines)
-		if lastLength > 0 {
-			_, _ = p.output.Write([]byte("\033[2A\r\033[J"))
+		// Clear the current progress frame
+		if lastLines > 0 {
+			_, _ = p.output.Write([]byte(clearRows(lastLines)))
 		}
 
 		var hint string
@@ -183,12 +251,16 @@ func (p *Progress) Stop(msg string, code int, opts ...StopOptions) {
 		// Write final state following clack pattern
 		var finalMsg string
 		if hint != "" {
-			finalMsg = fmt.Sprintf("%s\n%s  %s\n%s  %s\n", gray(Bar), symbol, msg, gray(Bar), gray(hint))
+			finalMsg = fmt.Sprintf("%s\n%s  %s\n%s  %s\n", th.muted(th.Bar), symbol, msg, th.muted(th.Bar), th.muted(hint))
 		} else {
-			finalMsg = fmt.Sprintf("%s\n%s  %s\n%s\n", gray(Bar), symbol, msg, gray(Bar))
+			finalMsg = fmt.Sprintf("%s\n%s  %s\n%s\n", th.muted(th.Bar), symbol, msg, th.muted(th.Bar))
 		}
 
-		_, _ = p.output.Write([]byte(finalMsg))
+		if accessible.Load() {
+			writeLine(p.output, plainFrame(finalMsg))
+		} else {
+			_, _ = p.output.Write([]byte(finalMsg))
+		}
 	}
 }
 
@@ -213,45 +285,65 @@ func (p *Progress) animate() {
 	}
 }
 
-// render draws the current progress bar frame.
+// render draws the current progress bar frame in place of the last one.
 func (p *Progress) render(msg string) {
+	p.renderMu.Lock()
+	defer p.renderMu.Unlock()
+
+	p.draw(msg)
+}
+
+// draw draws the current progress bar frame; the caller holds renderMu.
+// Nothing is drawn once the bar has stopped.
+func (p *Progress) draw(msg string) {
 	if p.output == nil {
 		return
 	}
 
+	th := resolveTheme(p.theme)
+
 	// Read current state
 	p.mu.Lock()
 	progress := float64(p.value) / float64(p.max)
 	filled := int(progress * float64(p.size))
 	frame := p.frames[p.frameIndex]
 	isActive := p.isActive
-	lastLength := p.lastFrameLength
+	lastLines := p.lastFrameLines
 	p.mu.Unlock()
 
+	if !isActive {
+		return
+	}
+
+	if accessible.Load() {
+		p.renderStatus(msg, int(progress*100.0))
+		return
+	}
+
 	// Get progress character
 	char, exists := progressChars[p.style]
 	if !exists {
 		char = "━" // fallback to heavy
 	}
 
-	// Build progress bar
-	filledBar := strings.Repeat(char, filled)
-	emptyBar := strings.Repeat(char, p.size-filled)
-
-	// Color the progress bar based on state
-	var coloredBar string
-	if isActive {
-		coloredBar = fmt.Sprintf("%s%s",
-			cyan(filledBar), // active progress in cyan
-			dim(emptyBar))   // remaining progress dimmed
-	} else {
-		coloredBar = fmt.Sprintf("%s%s",
-			green(filledBar), // completed progress in green
-			dim(emptyBar))
+	filledChar, emptyChar := char, char
+	if th.ProgressFilled != "" {
+		filledChar = th.ProgressFilled
 	}
 
+	if th.ProgressEmpty != "" {
+		emptyChar = th.ProgressEmpty
+	}
+
+	// Build progress bar
+	filledBar := strings.Repeat(filledChar, filled)
+	emptyBar := strings.Repeat(emptyChar, p.size-filled)
+
+	// Active progress, with the remaining progress dimmed
+	coloredBar := th.active(filledBar) + dim(emptyBar)
+
 	// Build frame following the clack visual pattern
-	output := fmt.Sprintf("%s\n%s  %s\n%s  %s", gray(Bar), cyan(frame), msg, cyan(Bar), coloredBar)
+	output := fmt.Sprintf("%s\n%s  %s\n%s  %s", th.muted(th.Bar), th.active(frame), msg, th.active(th.Bar), coloredBar)
 
 	// Emit OSC 9;4 set if percent changed, before writing frame
 	p.mu.Lock()
@@ -270,23 +362,35 @@ func (p *Progress) render(msg string) {
 	}
 
 	// Clear previous frame if this is not the first render
-	if lastLength > 0 {
-		// Progress bar has 3 lines, move up 2 and clear down
-		_, _ = p.output.Write([]byte("\033[2A\r\033[J"))
+	if lastLines > 0 {
+		_, _ = p.output.Write([]byte(clearRows(lastLines)))
 	}
 
 	// Write new frame
 	_, _ = p.output.Write([]byte(output))
 
-	// Update frame length for next clear
+	// Remember the rows it takes at this width for the next clear
 	p.mu.Lock()
-	p.lastFrameLength = len(removeAnsiCodes(output))
+	p.lastFrameLines = countPhysicalLines(output, getColumns(p.output))
 	p.mu.Unlock()
 }
 
-// removeAnsiCodes removes ANSI color codes to get actual display length.
-func removeAnsiCodes(s string) string {
-	// Simple regex to remove ANSI escape sequences
-	ansiRegex := regexp.MustCompile(`\x1b\[[0-9;]*m`)
-	return ansiRegex.ReplaceAllString(s, "")
+// renderStatus prints the message and percentage as a plain status line in
+// accessible mode, when the message changes, every 10%, and every
+// accessibleInterval otherwise.
+func (p *Progress) renderStatus(msg string, pct int) {
+	p.mu.Lock()
+
+	due := msg != p.statusMsg || pct/10 != p.statusPct/10 || time.Since(p.statusAt) >= accessibleInterval
+	if due {
+		p.statusMsg = msg
+		p.statusPct = pct
+		p.statusAt = time.Now()
+	}
+
+	p.mu.Unlock()
+
+	if due {
+		writeLine(p.output, fmt.Sprintf("%s %d%%", msg, pct))
+	}
 }
@@ -19,6 +19,8 @@ func TestProgress_RendersProgressBar(t *testing.T) {
 	})
 
 	prog.Start("Processing...")
+	defer prog.Stop("", 0)
+
 	time.Sleep(time.Millisecond)
 
 	frames := out.GetFrames()
@@ -42,6 +44,8 @@ func TestProgress_AdvancesProgress(t *testing.T) {
 	})
 
 	prog.Start("Loading...")
+	defer prog.Stop("", 0)
+
 	time.Sleep(time.Millisecond)
 
 	// Advance progress by 5
@@ -82,6 +86,8 @@ func TestProgress_DifferentStyles(t *testing.T) {
 			})
 
 			prog.Start("Test")
+			defer prog.Stop("", 0)
+
 			time.Sleep(time.Millisecond)
 
 			frames := out.GetFrames()
@@ -104,6 +110,8 @@ func TestProgress_CompletesToFullBar(t *testing.T) {
 	})
 
 	prog.Start("Starting...")
+	defer prog.Stop("", 0)
+
 	time.Sleep(time.Millisecond)
 
 	// Fill progress completely
@@ -132,6 +140,8 @@ func TestProgress_ClampsToMaxValue(t *testing.T) {
 	})
 
 	prog.Start("Starting...")
+	defer prog.Stop("", 0)
+
 	time.Sleep(time.Millisecond)
 
 	// Try to advance beyond max
@@ -160,6 +170,8 @@ func TestProgress_MessageOnly(t *testing.T) {
 	})
 
 	prog.Start("Starting...")
+	defer prog.Stop("", 0)
+
 	time.Sleep(time.Millisecond)
 
 	// Update message without advancing
@@ -245,3 +257,24 @@ func TestProgress_OSC94Signals(t *testing.T) {
 	frames2 := strings.Join(out2.GetFrames(), "")
 	assert.Contains(t, frames2, "\x1b]9;4;0\x1b\\")
 }
+
+func TestProgress_ResizeRedraws(t *testing.T) {
+	out := NewMockWritable()
+
+	prog := NewProgress(ProgressOptions{Output: out, Max: 10, Size: 20})
+	prog.Start("Processing...")
+	prog.Advance(5, "")
+
+	out.Emit("resize")
+
+	frames := out.GetFrames()
+	assert.Equal(t, clearRows(3), frames[len(frames)-2])
+	assert.Contains(t, frames[len(frames)-1], "Processing...")
+
+	prog.Stop("Done", 0)
+
+	n := len(out.GetFrames())
+	out.Emit("resize")
+
+	assert.Len(t, out.GetFrames(), n, "expected no redraw after Stop")
+}
@@ -3,13 +3,14 @@ package tap
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"slices"
 	"strings"
 	"sync/atomic"
+	"time"
 
 	"github.com/mattn/go-runewidth"
-	xterm "golang.org/x/term"
 
 	"github.com/yarlson/tap/internal/terminal"
 )
@@ -18,6 +19,13 @@ type PromptOptions struct {
 	Render           func(*Prompt) string
 	InitialValue     any
 	InitialUserInput string
+	MaxLength        int                  // rune limit for tracked input; 0 means unlimited
+	PasteNewlines    NewlinePolicy        // line-break handling for pastes into tracked input
+	Locale           *Locale              // catalog for built-in messages; nil uses the global locale
+	Announce         func(*Prompt) string // describes the current choice in accessible mode
+	MaxFPS           int                  // caps renders per second; 0 uses the global cap
+	AltScreen        bool                 // render in the alternate screen; the final frame is left on the main screen
+	Mouse            bool                 // report clicks and wheel turns as keys while the prompt is active
 	Validate         func(any) error
 	Input            Reader
 	Output           Writer
@@ -33,6 +41,7 @@ type Prompt struct {
 
 	evCh    chan<- func(*promptState) // Write-only: for sending events (never blocks with unbounded queue)
 	evOutCh <-chan func(*promptState) // Read-only: for receiving events in the loop
+	pending atomic.Int64              // events buffered in the queue, not yet received
 	doneCh  chan any
 	stopped chan struct{}
 
@@ -42,10 +51,18 @@ type Prompt struct {
 	snap        atomic.Value
 	inEventLoop atomic.Bool // true when inside event loop processing
 
-	track bool
+	track      bool
+	syncOutput bool // wrap frame updates in synchronized output
 
-	cleanup func()
-	cur     *promptState
+	// Release the restore registry entries for the hidden cursor, the
+	// alternate screen and mouse reporting once the prompt has undone them itself.
+	showCursor func()
+	exitAlt    func()
+	mouseOff   func()
+
+	cleanup   func()
+	offOutput func() // unregisters the resize and continue handlers
+	cur       *promptState
 }
 
 type promptState struct {
@@ -56,6 +73,35 @@ type promptState struct {
 	Cursor         int
 	PrevFrame      string
 	PrevFrameLines int
+
+	// resized is set when the terminal was resized since the last render.
+	resized bool
+
+	// continued is set when the process continued after a stop; the frame is
+	// drawn afresh below whatever the shell printed meanwhile.
+	continued bool
+
+	// stopping is set while a Ctrl+Z stop is in progress.
+	stopping bool
+
+	// notice is a transient message for the render function, cleared by the
+	// next key.
+	notice string
+
+	// frameTop is the screen row of the frame's first line, worked out from
+	// the terminal's reply to a position request; 0 until the reply arrives.
+	frameTop int
+
+	// positionLines is the height of the frame the last position request
+	// was written after.
+	positionLines int
+
+	// announced is the last announcement printed in accessible mode.
+	announced string
+
+	// consumed is set by a key handler that fully handled Return (e.g. Tags
+	// adding a chip) so the prompt does not validate and submit.
+	consumed bool
 }
 
 func (p *Prompt) StateSnapshot() ClackState {
@@ -83,6 +129,12 @@ func (p *Prompt) ValueSnapshot() any {
 	return s.Value
 }
 
+// noticeSnapshot returns the transient notice set by the last key.
+func (p *Prompt) noticeSnapshot() string {
+	s, _ := p.snap.Load().(promptState)
+	return s.notice
+}
+
 func (p *Prompt) snapshot() (state ClackState, prevFrame string) {
 	s, _ := p.snap.Load().(promptState)
 	return s.State, s.PrevFrame
@@ -109,6 +161,15 @@ func (p *Prompt) SetValue(v any) {
 	}
 }
 
+// enqueue schedules fn on the event loop. It is safe to call from any
+// goroutine; events sent after the prompt has finished are dropped.
+func (p *Prompt) enqueue(fn func(*promptState)) {
+	select {
+	case p.evCh <- fn:
+	case <-p.stopped:
+	}
+}
+
 // SetImmediateValue updates the value in the current event-loop tick if possible.
 // Falls back to enqueuing when called outside the loop.
 func (p *Prompt) SetImmediateValue(v any) {
@@ -122,7 +183,9 @@ func NewPrompt(options PromptOptions) *Prompt {
 
 // unboundedQueue creates an unbounded event queue by using a goroutine with a slice buffer.
 // Returns input and output channels. Input never blocks. Output delivers events in order.
-func unboundedQueue() (input chan<- func(*promptState), output <-chan func(*promptState)) {
+// pending is incremented for every buffered event; the receiver decrements it,
+// so while it is positive a receive from output does not block for long.
+func unboundedQueue(pending *atomic.Int64) (input chan<- func(*promptState), output <-chan func(*promptState)) {
 	in := make(chan func(*promptState))
 	out := make(chan func(*promptState))
 
@@ -138,6 +201,7 @@ func unboundedQueue() (input chan<- func(*promptState), output <-chan func(*prom
 				}
 
 				queue = append(queue, fn)
+				pending.Add(1)
 			} else {
 				// Queue has items, try to send the first one or receive more
 				select {
@@ -156,6 +220,7 @@ func unboundedQueue() (input chan<- func(*promptState), output <-chan func(*prom
 					}
 
 					queue = append(queue, fn)
+					pending.Add(1)
 				}
 			}
 		}
@@ -166,20 +231,19 @@ func unboundedQueue() (input chan<- func(*promptState), output <-chan func(*prom
 
 // NewPromptWithTracking creates a new prompt instance with specified tracking.
 func NewPromptWithTracking(options PromptOptions, trackValue bool) *Prompt {
-	evIn, evOut := unboundedQueue()
-
 	p := &Prompt{
 		input:       options.Input,
-		output:      options.Output,
+		output:      withColorProfile(options.Output),
 		opts:        options,
 		subscribers: make(map[string][]EventHandler),
 		preSubs:     make(map[string][]EventHandler),
 		track:       trackValue,
-		evCh:        evIn,
-		evOutCh:     evOut,
+		syncOutput:  options.Output != nil && supportsSynchronizedOutput(options.Output),
 		doneCh:      make(chan any, 1),
 		stopped:     make(chan struct{}),
 	}
+	p.evCh, p.evOutCh = unboundedQueue(&p.pending)
+
 	// Default TTY will be provided by a higher-level adapter when needed
 	p.snap.Store(promptState{State: StateInitial})
 
@@ -255,23 +319,31 @@ func (p *Prompt) Prompt(ctx context.Context) any {
 	// be ignored if it arrived before adoptPreSubscribers() ran in the loop goroutine.
 	p.adoptPreSubscribers()
 
-	go p.loop()
+	setActive(p, true)
 
-	if p.input != nil {
-		p.input.On("keypress", func(char string, key Key) {
+	// Registered before the loop starts, which unregisters them in finalize
+	if p.output != nil {
+		p.offOutput = onTerminalEvents(p.output, func() {
 			select {
-			case p.evCh <- func(s *promptState) {
-				p.handleKey(s, char, key)
-			}:
+			case p.evCh <- func(s *promptState) { p.handleResize(s) }:
+			case <-p.stopped:
+			}
+		}, func() {
+			select {
+			case p.evCh <- func(s *promptState) { s.continued = true }:
 			case <-p.stopped:
 			}
 		})
 	}
 
-	if p.output != nil {
-		p.output.On("resize", func() {
+	go p.loop()
+
+	if p.input != nil {
+		p.input.On("keypress", func(char string, key Key) {
 			select {
-			case p.evCh <- func(s *promptState) { p.handleResize(s) }:
+			case p.evCh <- func(s *promptState) {
+				p.handleKey(s, char, key)
+			}:
 			case <-p.stopped:
 			}
 		})
@@ -330,11 +402,68 @@ func getMovementAlias(keyName string) string {
 
 func (p *Prompt) handleInitialRender(_ *promptState) {}
 
-func (p *Prompt) handleResize(_ *promptState) {}
+// handleResize forces the next render to clear the previous frame using the
+// geometry it was drawn with and draw the frame again at the new width.
+func (p *Prompt) handleResize(s *promptState) { s.resized = true }
 
 func (p *Prompt) handleAbort(s *promptState) { s.State = StateCancel }
 
+// stopProcess stops the process for Ctrl+Z. Tests replace it.
+var stopProcess = terminal.StopProcess
+
+// handleSuspend stops the process on Ctrl+Z, which raw mode delivers as a
+// key. The stop runs off the event loop, so events keep being applied while
+// it lasts; rendering and finalizing wait, and the frame is drawn again
+// once the process continues.
+func (p *Prompt) handleSuspend(s *promptState) {
+	in, ok := p.input.(suspender)
+	if !ok || p.output == nil || s.stopping {
+		return
+	}
+
+	// Leave the cursor below the frame for the shell's job message
+	if p.exitAlt == nil {
+		_, _ = p.output.Write([]byte("\r\n"))
+	}
+
+	s.stopping = true
+
+	go func() {
+		_ = stopProcess(in.Suspend)
+
+		select {
+		case p.evCh <- func(s *promptState) {
+			s.stopping = false
+			s.continued = true
+		}:
+		case <-p.stopped:
+		}
+	}()
+}
+
 func (p *Prompt) handleKey(s *promptState, char string, key Key) {
+	if key.Ctrl && key.Name == "z" {
+		p.handleSuspend(s)
+		return
+	}
+
+	switch key.Name {
+	case "position":
+		// Reply to the position request written after the frame was drawn
+		s.frameTop = max(key.Y-s.positionLines+1, 1)
+		p.keepFrameOnScreen(s)
+
+		return
+	case "mouse":
+		return
+	case "wheelup":
+		key.Name = "up"
+	case "wheeldown":
+		key.Name = "down"
+	}
+
+	s.notice = ""
+
 	// Clear error on any keypress other than plain return/cancel (do this first).
 	// Shift+Return is editing, so it clears the error. Plain Return re-validates.
 	if s.State == StateError && (key.Name != "return" || key.Shift) && !isCancel(char, key) {
@@ -342,11 +471,30 @@ func (p *Prompt) handleKey(s *promptState, char string, key Key) {
 		s.Error = ""
 	}
 
+	// Pastes into tracked (single-line) input are sanitized first
+	if p.track && key.Name == "paste" {
+		content, err := sanitizePaste(key.Content, p.opts.PasteNewlines, resolveLocale(p.opts.Locale))
+		if err != nil {
+			s.Error = err.Error()
+			s.State = StateError
+		}
+
+		key.Content = content
+	}
+
 	// Track user input when tracking is enabled
 	if p.track && key.Name != "return" {
 		oldInput := s.UserInput
 		oldCursor := s.Cursor
 		newInput, newCursor := p.updateUserInputWithCursor(s.UserInput, s.Cursor, char, key)
+		if p.opts.MaxLength > 0 {
+			limited, limitedCursor := limitInput(oldInput, newInput, newCursor, p.opts.MaxLength)
+			if key.Name == "paste" && limited != newInput {
+				s.notice = resolveLocale(p.opts.Locale).PasteTruncated
+			}
+
+			newInput, newCursor = limited, limitedCursor
+		}
 
 		inputChanged := newInput != oldInput
 		cursorChanged := newCursor != oldCursor
@@ -359,18 +507,13 @@ func (p *Prompt) handleKey(s *promptState, char string, key Key) {
 		if cursorChanged {
 			s.Cursor = newCursor
 		}
-
-		// Force re-render when state changes
-		if inputChanged || cursorChanged {
-			s.PrevFrame = ""
-		}
 	}
 
 	if isMovementKey(key.Name) {
 		p.Emit("cursor", key.Name)
 	}
 
-	if alias := getMovementAlias(key.Name); !p.track && alias != "" {
+	if alias := getMovementAlias(key.Name); !p.track && !key.Ctrl && alias != "" {
 		p.Emit("cursor", alias)
 	}
 
@@ -384,6 +527,11 @@ func (p *Prompt) handleKey(s *promptState, char string, key Key) {
 
 	p.Emit("key", strings.ToLower(char), key)
 
+	if s.consumed {
+		s.consumed = false
+		return
+	}
+
 	if key.Name == "return" && !key.Shift {
 		// For text input tracking, set value from user input if no value is set
 		if p.track && s.Value == nil {
@@ -480,6 +628,11 @@ func (p *Prompt) updateUserInputWithCursor(current string, cursor int, char stri
 		// Insert space at cursor
 		return string(slices.Insert(runes, cursor, ' ')), cursor + 1
 
+	case "paste":
+		// Insert pasted content at cursor
+		pasted := []rune(key.Content)
+		return string(slices.Insert(runes, cursor, pasted...)), cursor + len(pasted)
+
 	default:
 		// Regular printable characters - insert at cursor position
 		if char != "" {
@@ -497,37 +650,110 @@ func (p *Prompt) updateUserInputWithCursor(current string, cursor int, char stri
 	}
 }
 
+// limitInput drops the runes just inserted before cursor that would take the
+// input past limit. Input that was already longer (e.g. an initial value) may
+// still shrink but not grow.
+func limitInput(oldInput, newInput string, cursor, limit int) (string, int) {
+	runes := []rune(newInput)
+
+	allowed := max(limit, len([]rune(oldInput)))
+	if len(runes) <= allowed {
+		return newInput, cursor
+	}
+
+	excess := min(len(runes)-allowed, cursor)
+
+	return string(slices.Delete(runes, cursor-excess, cursor)), cursor - excess
+}
+
 func (p *Prompt) loop() {
+	// A panicking render function or key handler takes the program down, so
+	// every terminal mode is restored first.
+	defer func() {
+		if r := recover(); r != nil {
+			terminal.Restore()
+			panic(r)
+		}
+	}()
+
 	st := promptState{State: StateInitial}
 
 	// Note: adoptPreSubscribers() is now called synchronously in Prompt() before
 	// the loop starts, to avoid a race condition with keypress events.
 	p.snap.Store(st)
 
-	for ev := range p.evOutCh {
-		p.cur = &st
-		p.inEventLoop.Store(true)
+	var (
+		lastRender time.Time
+		deferred   <-chan time.Time // fires when a render held back by the FPS cap is due
+	)
 
-		ev(&st)
+	for {
+		select {
+		case ev := <-p.evOutCh:
+			p.pending.Add(-1)
+			p.apply(&st, ev)
+			// Events already queued are applied before rendering, so a burst
+			// of input renders once.
+			p.drain(&st)
+		case <-deferred:
+			deferred = nil
+		}
 
-		p.inEventLoop.Store(false)
-		p.renderIfNeeded(&st)
-		p.snap.Store(st)
+		// The terminal belongs to the shell until a Ctrl+Z stop is over
+		if st.stopping {
+			p.snap.Store(st)
+			continue
+		}
 
+		// The final state is always rendered, regardless of the FPS cap.
 		if p.shouldFinalize(st.State) {
 			p.renderIfNeeded(&st)
 			p.snap.Store(st)
 
 			res := p.finalize(&st)
+			setActive(p, false)
 			p.doneCh <- res
 
 			close(p.stopped)
-			p.cur = nil
 
 			return
 		}
 
-		p.cur = nil
+		if deferred != nil {
+			continue
+		}
+
+		if wait := frameInterval(p.opts.MaxFPS) - time.Since(lastRender); wait > 0 {
+			deferred = time.After(wait)
+			continue
+		}
+
+		p.renderIfNeeded(&st)
+		p.snap.Store(st)
+
+		lastRender = time.Now()
+	}
+}
+
+// apply runs a single event against the loop state.
+func (p *Prompt) apply(st *promptState, ev func(*promptState)) {
+	p.cur = st
+	p.inEventLoop.Store(true)
+
+	ev(st)
+
+	p.inEventLoop.Store(false)
+	p.snap.Store(*st)
+	p.cur = nil
+}
+
+// drain applies the events that are already queued, stopping early once the
+// prompt is ready to finalize.
+func (p *Prompt) drain(st *promptState) {
+	for p.pending.Load() > 0 && !p.shouldFinalize(st.State) {
+		ev := <-p.evOutCh
+		p.pending.Add(-1)
+		p.apply(st, ev)
 	}
 }
 
@@ -539,29 +765,40 @@ func (p *Prompt) adoptPreSubscribers() {
 	}
 }
 
-// Detect terminal width; fall back to 80.
-func getColumns() int {
-	fd := int(os.Stdout.Fd())
-	if cols, _, err := xterm.GetSize(fd); err == nil && cols > 0 {
+// Terminal width of w; fall back to 80.
+func getColumns(w Writer) int {
+	if cols, _ := terminalSize(w); cols > 0 {
 		return cols
 	}
 
 	return 80
 }
 
+// Terminal height of w; 0 when unknown.
+func getRows(w Writer) int {
+	_, rows := terminalSize(w)
+	return max(rows, 0)
+}
+
+// TerminalSize returns the columns and rows of the terminal the prompt
+// renders to, or zero for a dimension that is unknown. Render functions can
+// use it to fit their output.
+func (p *Prompt) TerminalSize() (cols, rows int) {
+	return terminalSize(p.output)
+}
+
 // Printable width ignoring ANSI; rune-count approximation.
 func visibleWidth(s string) int {
 	clean := ansiRegexp.ReplaceAllString(s, "")
 	return runewidth.StringWidth(clean)
 }
 
-// Rows occupied by frame, accounting for soft-wrapping.
-func countPhysicalLines(s string) int {
+// Rows occupied by frame at cols columns, accounting for soft-wrapping.
+func countPhysicalLines(s string, cols int) int {
 	if s == "" {
 		return 0
 	}
 
-	cols := getColumns()
 	if cols <= 0 {
 		cols = 80
 	}
@@ -585,7 +822,7 @@ func countPhysicalLines(s string) int {
 
 // renderIfNeeded runs the render function, hides the cursor on the first frame,
 // writes the frame, and updates state to active. It only writes when the frame
-// content changes.
+// content changes, and then only the lines that changed.
 func (p *Prompt) renderIfNeeded(st *promptState) {
 	if p.opts.Render == nil || p.output == nil {
 		return
@@ -596,37 +833,115 @@ func (p *Prompt) renderIfNeeded(st *promptState) {
 	p.snap.Store(*st)
 
 	frame := p.opts.Render(p)
-	if frame == st.PrevFrame {
+	if frame == st.PrevFrame && !st.resized && !st.continued {
 		return
 	}
 
-	if st.State == StateInitial {
-		_, _ = p.output.Write([]byte(CursorHide))
-	} else {
-		// Clear previous frame
-		if st.PrevFrameLines > 1 {
-			// Multi-line frame: move cursor up and clear from current position down
-			for i := 0; i < st.PrevFrameLines-1; i++ {
-				_, _ = p.output.Write([]byte(CursorUp))
-			}
+	if accessible.Load() {
+		p.renderLinear(st, frame)
+		return
+	}
+
+	// The update is assembled into a single write so the terminal never
+	// shows a partially drawn frame.
+	var b strings.Builder
+
+	if p.syncOutput {
+		b.WriteString(syncOutputBegin)
+	}
+
+	cols := getColumns(p.output)
+	requestPos := st.State == StateInitial || st.resized || st.continued
+
+	switch {
+	case st.State == StateInitial && p.opts.AltScreen:
+		p.exitAlt = p.trackMode(altScreenExit, altScreenEnter)
+		p.showCursor = p.trackMode(CursorShow, CursorHide)
+		b.WriteString(altScreenEnter + cursorHome + EraseDown + CursorHide + frame)
+	case st.State == StateInitial:
+		p.showCursor = p.trackMode(CursorShow, CursorHide)
+		b.WriteString(CursorHide + frame)
+	case (st.resized || st.continued) && p.exitAlt != nil:
+		// The alternate screen holds only the frame, so it is redrawn from the top
+		b.WriteString(cursorHome + EraseDown + frame)
+	case st.continued:
+		b.WriteString(frame)
+	case st.resized:
+		// Line positions are unknown after a resize, so the frame is redrawn
+		b.WriteString(clearRows(st.PrevFrameLines) + frame)
+	case st.PrevFrame == "":
+		// Nothing to diff against, e.g. after an external program drew over
+		// the screen; the rows of the old frame are replaced as a whole
+		b.WriteString(clearRows(st.PrevFrameLines) + frame)
+	default:
+		b.WriteString(frameUpdate(st.PrevFrame, frame, st.PrevFrameLines, cols))
+	}
 
-			_, _ = p.output.Write([]byte("\r"))
-			_, _ = p.output.Write([]byte(EraseDown))
-		} else {
-			// Single-line frame: use fast single-line clear
-			_, _ = p.output.Write([]byte("\r"))
-			_, _ = p.output.Write([]byte(EraseLine))
+	if p.opts.Mouse {
+		if st.State == StateInitial {
+			p.mouseOff = p.trackMode(mouseDisable, mouseEnable)
+			b.WriteString(mouseEnable)
 		}
+
+		// Ask where the frame ended up whenever it may have moved, so clicks
+		// can be mapped to its lines
+		if requestPos {
+			terminal.ExpectPosition()
+			b.WriteString(requestPosition)
+		}
+	}
+
+	if p.syncOutput {
+		b.WriteString(syncOutputEnd)
 	}
 
-	_, _ = p.output.Write([]byte(frame))
+	_, _ = p.output.Write([]byte(b.String()))
 
 	if st.State == StateInitial {
 		st.State = StateActive
 	}
 
 	st.PrevFrame = frame
-	st.PrevFrameLines = countPhysicalLines(frame)
+	st.PrevFrameLines = countPhysicalLines(frame, cols)
+
+	if p.opts.Mouse && requestPos {
+		st.positionLines = st.PrevFrameLines
+	}
+
+	p.keepFrameOnScreen(st)
+
+	st.resized = false
+	st.continued = false
+}
+
+// renderLinear is the accessible-mode renderer. It prints the first and the
+// final frame as plain text and, in between, only announcements of errors
+// and of the current choice, each on a new line.
+func (p *Prompt) renderLinear(st *promptState, frame string) {
+	var announcement string
+
+	switch {
+	case st.State == StateError:
+		announcement = fmt.Sprintf(resolveLocale(p.opts.Locale).ErrorAnnouncement, st.Error)
+	case p.opts.Announce != nil:
+		announcement = p.opts.Announce(p)
+	}
+
+	switch st.State {
+	case StateInitial, StateSubmit, StateCancel:
+		writeLine(p.output, plainFrame(frame))
+	default:
+		if announcement != st.announced {
+			writeLine(p.output, announcement)
+		}
+	}
+
+	if st.State == StateInitial {
+		st.State = StateActive
+	}
+
+	st.announced = announcement
+	st.PrevFrame = frame
 }
 
 func (p *Prompt) shouldFinalize(state ClackState) bool {
@@ -638,11 +953,32 @@ func (p *Prompt) shouldFinalize(state ClackState) bool {
 func (p *Prompt) finalize(st *promptState) any {
 	p.Emit("finalize")
 	// Write trailing newline and show the cursor again
-	if p.output != nil {
+	if p.output != nil && !accessible.Load() {
+		if p.mouseOff != nil {
+			_, _ = p.output.Write([]byte(mouseDisable))
+		}
+
+		// Only the final frame is left in the main screen's scrollback
+		if p.leaveAltScreen() {
+			_, _ = p.output.Write([]byte(st.PrevFrame))
+		}
+
 		_, _ = p.output.Write([]byte("\r\n"))
 		_, _ = p.output.Write([]byte(CursorShow))
 	}
 
+	if p.showCursor != nil {
+		p.showCursor()
+	}
+
+	if p.mouseOff != nil {
+		p.mouseOff()
+	}
+
+	if p.offOutput != nil {
+		p.offOutput()
+	}
+
 	if p.cleanup != nil {
 		p.cleanup()
 	}
@@ -671,45 +1007,77 @@ func runWithTerminal[T any](fn func(Reader, Writer) T) T {
 		return fn(ioReader, ioWriter)
 	}
 
-	t, err := terminal.New()
+	in, out, release, err := openTerminal()
 	if err != nil {
 		var zero T
 		return zero
 	}
+	defer release()
 
-	return fn(t.Reader, t.Writer)
+	return fn(in, out)
+}
+
+// openTerminal opens the shared terminal for one prompt. release gives it
+// back; the last release takes the TTY out of raw mode. Tests replace it.
+var openTerminal = func() (in Reader, out Writer, release func(), err error) {
+	t, err := terminal.New()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return t.Reader, t.Writer, t.Close, nil
 }
 
 // resolveWriter returns the output writer for simple output operations
-// (like Intro, Outro, Message). It uses a lightweight stdout wrapper that
-// doesn't start a readKeys goroutine, preventing zombie terminals from
-// stealing keypresses from interactive prompts.
+// (like Intro, Outro, Message). It uses a lightweight wrapper of the output
+// file that doesn't start a readKeys goroutine, preventing zombie terminals
+// from stealing keypresses from interactive prompts.
 func resolveWriter() Writer {
 	// Check if we have override I/O set
 	if out := getOverrideWriter(); out != nil {
 		return out
 	}
 
-	// Return a simple stdout writer - no full terminal needed for output-only operations.
+	// Return a simple file writer - no full terminal needed for output-only operations.
 	// This avoids the bug where resolveWriter would create a terminal that keeps reading
 	// keys and interferes with interactive prompts.
-	return &stdoutWriter{}
+	return &fileWriter{file: terminal.Output()}
+}
+
+// fileWriter is a simple Writer implementation that writes to a file, stdout
+// unless SetOutputTarget chose another. It doesn't start any goroutines or
+// open a TTY, making it safe to use for output-only utilities like Intro,
+// Outro, Message, etc.
+type fileWriter struct {
+	file *os.File
+}
+
+func (w *fileWriter) Write(p []byte) (int, error) {
+	return w.file.Write(p)
 }
 
-// stdoutWriter is a simple Writer implementation that writes to stdout.
-// It doesn't start any goroutines or open a TTY, making it safe to use
-// for output-only utilities like Intro, Outro, Message, etc.
-type stdoutWriter struct{}
+// Fd returns the file descriptor written to, for capability detection.
+func (w *fileWriter) Fd() uintptr { return w.file.Fd() }
 
-func (w *stdoutWriter) Write(p []byte) (int, error) {
-	return os.Stdout.Write(p)
+// On registers handler for terminal resize and process continue events;
+// other events are ignored.
+func (w *fileWriter) On(event string, handler func()) {
+	_ = w.OnWithOff(event, handler)
 }
 
-func (w *stdoutWriter) On(_ string, _ func()) {
-	// No-op: output-only writer doesn't need resize handling
+// OnWithOff implements Unsubscriber.
+func (w *fileWriter) OnWithOff(event string, handler func()) func() {
+	switch event {
+	case "resize":
+		return terminal.OnResize(handler)
+	case "continue":
+		return terminal.OnContinue(handler)
+	}
+
+	return func() {}
 }
 
-func (w *stdoutWriter) Emit(_ string) {
+func (w *fileWriter) Emit(_ string) {
 	// No-op: output-only writer doesn't emit events
 }
 
@@ -3,6 +3,7 @@ package tap
 import (
 	"context"
 	"errors"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -36,7 +37,7 @@ func TestPrompt_RendersRenderResult(t *testing.T) {
 	input.EmitKeypress("\x03", Key{Name: "c", Ctrl: true})
 	<-done
 
-	expected := []string{"\x1b[?25l", "foo", "\r\n", "\x1b[?25h"} // cursor.hide + "foo" + newline + cursor.show
+	expected := []string{"\x1b[?25lfoo", "\r\n", "\x1b[?25h"} // cursor.hide with "foo" in one write, newline, cursor.show
 	assert.Equal(t, expected, output.Buffer)
 }
 
@@ -72,7 +73,7 @@ func TestPrompt_SubmitsOnReturn(t *testing.T) {
 	assert.Equal(t, nil, result)
 	assert.Equal(t, StateSubmit, p.StateSnapshot())
 
-	expectedOutput := []string{"\x1b[?25l", "foo", "\r\n", "\x1b[?25h"}
+	expectedOutput := []string{"\x1b[?25lfoo", "\r\n", "\x1b[?25h"}
 	assert.Equal(t, expectedOutput, output.Buffer)
 }
 
@@ -108,7 +109,7 @@ func TestPrompt_CancelsOnCtrlC(t *testing.T) {
 	assert.Nil(t, result)
 	assert.Equal(t, StateCancel, p.StateSnapshot())
 
-	expectedOutput := []string{"\x1b[?25l", "foo", "\r\n", "\x1b[?25h"}
+	expectedOutput := []string{"\x1b[?25lfoo", "\r\n", "\x1b[?25h"}
 	assert.Equal(t, expectedOutput, output.Buffer)
 }
 
@@ -328,6 +329,130 @@ func TestPrompt_ReRendersOnResize(t *testing.T) {
 	input.EmitKeypress("\x03", Key{Name: "c", Ctrl: true})
 }
 
+func TestPrompt_ResizeRedrawsUnchangedFrame(t *testing.T) {
+	input := NewMockReadable()
+	output := NewMockWritable()
+
+	p := NewPrompt(PromptOptions{
+		Input:  input,
+		Output: output,
+		Render: func(_ *Prompt) string { return "foo\nbar" },
+	})
+
+	go p.Prompt(context.Background())
+
+	time.Sleep(time.Millisecond)
+
+	output.Emit("resize")
+	time.Sleep(time.Millisecond)
+
+	frames := output.GetFrames()
+	assert.Equal(t, clearRows(2)+"foo\nbar", frames[len(frames)-1])
+	assert.Equal(t, "foo\nbar", screenOf(output))
+
+	input.EmitKeypress("\x03", Key{Name: "c", Ctrl: true})
+}
+
+func TestPrompt_RedrawsAfterContinue(t *testing.T) {
+	input := NewMockReadable()
+	output := NewMockWritable()
+
+	p := NewPrompt(PromptOptions{
+		Input:  input,
+		Output: output,
+		Render: func(_ *Prompt) string { return "foo\nbar" },
+	})
+
+	go p.Prompt(context.Background())
+
+	time.Sleep(time.Millisecond)
+
+	// The shell printed its job messages below the frame meanwhile
+	_, _ = output.Write([]byte("\r\n[1]+ Stopped\r\n$ fg\r\n"))
+	output.Emit("continue")
+	time.Sleep(time.Millisecond)
+
+	frames := output.GetFrames()
+	assert.Equal(t, "foo\nbar", frames[len(frames)-1])
+
+	input.EmitKeypress("\x03", Key{Name: "c", Ctrl: true})
+}
+
+func TestPrompt_CtrlZWithoutTerminalIsIgnored(t *testing.T) {
+	input := NewMockReadable()
+	output := NewMockWritable()
+
+	p := NewPromptWithTracking(PromptOptions{
+		Input:  input,
+		Output: output,
+		Render: func(p *Prompt) string { return "value: " + p.UserInputSnapshot() },
+	}, true)
+
+	done := make(chan any, 1)
+
+	go func() { done <- p.Prompt(context.Background()) }()
+
+	time.Sleep(time.Millisecond)
+	input.EmitKeypress("", Key{Name: "z", Ctrl: true})
+	input.EmitKeypress("a", Key{Name: "a", Rune: 'a'})
+	input.EmitKeypress("", Key{Name: "return"})
+
+	assert.Equal(t, "a", <-done)
+}
+
+// suspendableInput is a MockReadable that can hand the terminal over.
+type suspendableInput struct{ *MockReadable }
+
+func (suspendableInput) Suspend() (func() error, error) { return func() error { return nil }, nil }
+
+func TestPrompt_CtrlZStopsOffTheEventLoop(t *testing.T) {
+	input := suspendableInput{NewMockReadable()}
+	output := NewMockWritable()
+
+	stopped := make(chan struct{})
+	cont := make(chan struct{})
+
+	orig := stopProcess
+	stopProcess = func(func() (func() error, error)) error {
+		close(stopped)
+		<-cont
+
+		return nil
+	}
+
+	defer func() { stopProcess = orig }()
+
+	p := NewPromptWithTracking(PromptOptions{
+		Input:  input,
+		Output: output,
+		Render: func(p *Prompt) string { return "value: " + p.UserInputSnapshot() },
+	}, true)
+
+	done := make(chan any, 1)
+
+	go func() { done <- p.Prompt(context.Background()) }()
+
+	time.Sleep(time.Millisecond)
+	input.EmitKeypress("", Key{Name: "z", Ctrl: true})
+	<-stopped
+
+	// Events are applied while the process is stopped, but nothing is drawn
+	n := len(output.GetFrames())
+	input.EmitKeypress("a", Key{Name: "a", Rune: 'a'})
+	output.Emit("resize")
+	time.Sleep(5 * time.Millisecond)
+
+	assert.Equal(t, "a", p.UserInputSnapshot())
+	assert.Len(t, output.GetFrames(), n)
+
+	close(cont)
+	time.Sleep(5 * time.Millisecond)
+	assert.Contains(t, strings.Join(output.GetFrames()[n:], ""), "value: a")
+
+	input.EmitKeypress("", Key{Name: "return"})
+	assert.Equal(t, "a", <-done)
+}
+
 func TestPrompt_StateIsActiveAfterFirstRender(t *testing.T) {
 	input := NewMockReadable()
 	output := NewMockWritable()
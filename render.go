@@ -0,0 +1,202 @@
+package tap
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	xterm "golang.org/x/term"
+)
+
+// Synchronized output (DEC private mode 2026) escape sequences. A terminal
+// holds the screen while the mode is set and paints the update at once.
+const (
+	syncOutputBegin = "\x1b[?2026h"
+	syncOutputEnd   = "\x1b[?2026l"
+)
+
+// SynchronizedOutputter is implemented by Writers that know whether they
+// support synchronized output (DEC mode 2026). It takes precedence over
+// detection.
+type SynchronizedOutputter interface {
+	SynchronizedOutput() bool
+}
+
+// Sizer is implemented by Writers that know the size of the terminal they
+// write to, such as a pty or an SSH channel. Renderers use it for wrapping,
+// truncation and viewport sizing, and redraw when the Writer emits "resize".
+// A dimension of zero means unknown.
+type Sizer interface {
+	Size() (cols, rows int)
+}
+
+// terminalSize returns the size reported by w, or the size of the terminal
+// behind its file descriptor. It returns zeros when neither is available.
+func terminalSize(w Writer) (cols, rows int) {
+	if s, ok := w.(Sizer); ok {
+		return s.Size()
+	}
+
+	f, ok := w.(fdWriter)
+	if !ok {
+		return 0, 0
+	}
+
+	cols, rows, err := xterm.GetSize(int(f.Fd()))
+	if err != nil {
+		return 0, 0
+	}
+
+	return cols, rows
+}
+
+// Unsubscriber is implemented by Writers whose event handlers can be
+// unregistered. OnWithOff registers handler like On and returns a func that
+// unregisters it. With other Writers, handlers added by prompts and
+// utilities stay registered but do nothing once those have finished.
+type Unsubscriber interface {
+	OnWithOff(event string, handler func()) (off func())
+}
+
+// onWithOff registers handler for event on w and returns a func that
+// unregisters it, or disables it when w is not an Unsubscriber.
+func onWithOff(w Writer, event string, handler func()) (off func()) {
+	if u, ok := w.(Unsubscriber); ok {
+		return u.OnWithOff(event, handler)
+	}
+
+	var disabled atomic.Bool
+
+	w.On(event, func() {
+		if !disabled.Load() {
+			handler()
+		}
+	})
+
+	return func() { disabled.Store(true) }
+}
+
+// onTerminalEvents registers handlers for the "resize" and "continue" events
+// of w and returns a func that unregisters both.
+func onTerminalEvents(w Writer, resize, cont func()) (off func()) {
+	offResize := onWithOff(w, "resize", resize)
+	offContinue := onWithOff(w, "continue", cont)
+
+	return func() {
+		offResize()
+		offContinue()
+	}
+}
+
+// supportsSynchronizedOutput reports whether frame updates written to w should
+// be wrapped in synchronized output. Terminals are assumed to support it, as
+// those that do not ignore the mode.
+func supportsSynchronizedOutput(w Writer) bool {
+	if s, ok := w.(SynchronizedOutputter); ok {
+		return s.SynchronizedOutput()
+	}
+
+	f, ok := w.(fdWriter)
+	if !ok || !xterm.IsTerminal(int(f.Fd())) {
+		return false
+	}
+
+	return os.Getenv("TERM") != "dumb"
+}
+
+// frameUpdate returns the output that turns prev, a frame occupying prevRows
+// physical rows with the cursor on its last row, into next. Only lines that
+// differ are rewritten; when a line of either frame soft-wraps at cols, the
+// whole frame is redrawn instead.
+func frameUpdate(prev, next string, prevRows, cols int) string {
+	var b strings.Builder
+
+	prevLines := strings.Split(prev, "\n")
+	nextLines := strings.Split(next, "\n")
+
+	if wrapsAt(prevLines, cols) || wrapsAt(nextLines, cols) {
+		return clearRows(prevRows) + next
+	}
+
+	last := len(nextLines) - 1
+	shrinks := len(nextLines) < len(prevLines)
+
+	first := 0
+	for first < last && first < len(prevLines) && prevLines[first] == nextLines[first] {
+		first++
+	}
+
+	// Move from the last row of prev to the first row that changes.
+	if up := len(prevLines) - 1 - first; up > 0 {
+		b.WriteString("\x1b[" + strconv.Itoa(up) + "A")
+	} else if up < 0 {
+		b.WriteString(strings.Repeat("\n", -up))
+	}
+
+	for i := first; i <= last; i++ {
+		if i > first {
+			b.WriteString("\n")
+		}
+
+		// The last line is rewritten when the frame shrinks so the cursor ends
+		// up after it before the rows below are cleared.
+		unchanged := i < len(prevLines) && prevLines[i] == nextLines[i]
+		if unchanged && (i < last || !shrinks) {
+			continue
+		}
+
+		b.WriteString("\r" + nextLines[i] + "\x1b[K")
+	}
+
+	if shrinks {
+		b.WriteString(EraseDown)
+	}
+
+	return b.String()
+}
+
+// clearRows returns the output that erases a frame occupying rows physical
+// rows, with the cursor on its last row, leaving the cursor at its start.
+func clearRows(rows int) string {
+	if rows > 1 {
+		return strings.Repeat(CursorUp, rows-1) + "\r" + EraseDown
+	}
+
+	return EraseLine
+}
+
+// wrapsAt reports whether any line is too wide to fit in cols columns
+// without soft-wrapping.
+func wrapsAt(lines []string, cols int) bool {
+	for _, line := range lines {
+		if visibleWidth(line) >= cols {
+			return true
+		}
+	}
+
+	return false
+}
+
+// maxFPS is the global render rate cap for prompts; 0 means uncapped.
+var maxFPS atomic.Int64
+
+// SetMaxFPS caps how many frames per second prompts render; bursts of input
+// in between are coalesced into the next frame. Zero or less removes the cap.
+// PromptOptions.MaxFPS overrides it for a single prompt.
+func SetMaxFPS(fps int) { maxFPS.Store(int64(fps)) }
+
+// frameInterval returns the minimum time between renders for fps, falling
+// back to the global cap when fps is zero.
+func frameInterval(fps int) time.Duration {
+	if fps == 0 {
+		fps = int(maxFPS.Load())
+	}
+
+	if fps <= 0 {
+		return 0
+	}
+
+	return time.Second / time.Duration(fps)
+}
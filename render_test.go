@@ -0,0 +1,450 @@
+package tap
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// replay applies writes to a virtual screen and returns its lines. It
+// understands the cursor movement and erase sequences used by the renderer;
+// SGR sequences are kept as text and other escape sequences are ignored.
+func replay(writes ...string) []string {
+	screen := []string{""}
+	row, col := 0, 0
+
+	for _, w := range writes {
+		for i := 0; i < len(w); {
+			switch c := w[i]; {
+			case c == '\r':
+				col = 0
+				i++
+			case c == '\n':
+				row++
+				if row == len(screen) {
+					screen = append(screen, "")
+				}
+
+				col = 0
+				i++
+			case c == 0x1b && i+1 < len(w) && w[i+1] == '[':
+				j := i + 2
+				for j < len(w) && (w[j] < 0x40 || w[j] > 0x7e) {
+					j++
+				}
+
+				seq, params, final := w[i:j+1], w[i+2:j], w[j]
+				i = j + 1
+
+				switch final {
+				case 'A':
+					n, err := strconv.Atoi(params)
+					if err != nil {
+						n = 1
+					}
+
+					row = max(row-n, 0)
+					col = min(col, len(screen[row]))
+				case 'K':
+					screen[row] = screen[row][:col]
+				case 'J':
+					screen[row] = screen[row][:col]
+					screen = screen[:row+1]
+				case 'm':
+					screen[row] = screen[row][:col] + seq
+					col = len(screen[row])
+				}
+			case c == 0x1b && i+1 < len(w) && w[i+1] == ']':
+				// OSC sequences end with BEL
+				end := strings.IndexByte(w[i:], '\a')
+				if end < 0 {
+					i = len(w)
+				} else {
+					i += end + 1
+				}
+			default:
+				j := i
+				for j < len(w) && w[j] != '\r' && w[j] != '\n' && w[j] != 0x1b {
+					j++
+				}
+
+				screen[row] = screen[row][:min(col, len(screen[row]))] + w[i:j]
+				col = len(screen[row])
+				i = j
+			}
+		}
+	}
+
+	return screen
+}
+
+// screenOf returns what out currently shows.
+func screenOf(out *MockWritable) string {
+	return strings.Join(replay(out.GetFrames()...), "\n")
+}
+
+func TestFrameUpdate_RewritesOnlyChangedLines(t *testing.T) {
+	prev := "title\n│  one\n│  two\n└"
+	next := "title\n│  one\n│  TWO\n└"
+
+	got := frameUpdate(prev, next, 4, 80)
+	if want := "\x1b[1A\r│  TWO\x1b[K\n"; got != want {
+		t.Errorf("frameUpdate() = %q, want %q", got, want)
+	}
+}
+
+func TestFrameUpdate_ReplaysToNextFrame(t *testing.T) {
+	tests := []struct {
+		name, prev, next string
+	}{
+		{"change middle", "a\nb\nc", "a\nB\nc"},
+		{"change first", "a\nb\nc", "A\nb\nc"},
+		{"grow", "a\nb", "a\nb\nc\nd"},
+		{"grow and change", "a\nb", "a\nB\nc"},
+		{"shrink", "a\nb\nc\nd", "a\nb"},
+		{"shrink and change", "a\nb\nc", "A\nb"},
+		{"trailing newline", "a\nb\n", "a\nc\n"},
+		{"single line", "abc", "abd"},
+		{"to empty last line", "a\nb", "a\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update := frameUpdate(tt.prev, tt.next, strings.Count(tt.prev, "\n")+1, 80)
+
+			got := strings.Join(replay(tt.prev, update), "\n")
+			if got != tt.next {
+				t.Errorf("replay = %q, want %q (update %q)", got, tt.next, update)
+			}
+		})
+	}
+}
+
+func TestFrameUpdate_RedrawsWhenLinesWrap(t *testing.T) {
+	prev := "a\n" + strings.Repeat("x", 10)
+	next := "a\ny"
+
+	got := frameUpdate(prev, next, 3, 10)
+	if !strings.HasPrefix(got, CursorUp+CursorUp+"\r"+EraseDown) || !strings.HasSuffix(got, next) {
+		t.Errorf("expected full redraw, got %q", got)
+	}
+}
+
+func TestPrompt_SynchronizedOutput(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+	out.SetSynchronizedOutput(true)
+
+	p := NewPrompt(PromptOptions{
+		Input:  in,
+		Output: out,
+		Render: func(p *Prompt) string { return "frame " + p.UserInputSnapshot() },
+	})
+
+	done := make(chan any, 1)
+
+	go func() { done <- p.Prompt(context.Background()) }()
+
+	time.Sleep(time.Millisecond)
+	in.EmitKeypress("a", Key{Name: "a", Rune: 'a'})
+	in.EmitKeypress("", Key{Name: "return"})
+	<-done
+
+	frames := out.GetFrames()
+	if len(frames) < 2 {
+		t.Fatalf("expected frame writes, got %q", frames)
+	}
+
+	for _, f := range frames[:2] {
+		if !strings.HasPrefix(f, syncOutputBegin) || !strings.HasSuffix(f, syncOutputEnd) {
+			t.Errorf("expected update wrapped in synchronized output, got %q", f)
+		}
+	}
+
+	if got := screenOf(out); !strings.HasPrefix(got, "frame a") {
+		t.Errorf("expected final frame on screen, got %q", got)
+	}
+}
+
+func TestPrompt_CoalescesQueuedEvents(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	var renders atomic.Int32
+
+	p := NewPrompt(PromptOptions{
+		Input:  in,
+		Output: out,
+		Render: func(p *Prompt) string {
+			renders.Add(1)
+			return "value: " + p.UserInputSnapshot()
+		},
+	})
+
+	done := make(chan any, 1)
+
+	go func() { done <- p.Prompt(context.Background()) }()
+
+	time.Sleep(time.Millisecond)
+
+	// Hold the loop while a burst of keys is queued behind it.
+	release := make(chan struct{})
+	p.enqueue(func(*promptState) { <-release })
+
+	for range 20 {
+		in.EmitKeypress("x", Key{Name: "x", Rune: 'x'})
+	}
+
+	before := renders.Load()
+
+	close(release)
+	in.EmitKeypress("", Key{Name: "return"})
+
+	if got := <-done; got != strings.Repeat("x", 20) {
+		t.Fatalf("expected all keys applied, got %v", got)
+	}
+
+	// The burst renders once, plus the final frame.
+	if n := renders.Load() - before; n > 2 {
+		t.Errorf("expected the burst to be coalesced, got %d renders", n)
+	}
+
+	if got := strings.TrimSuffix(screenOf(out), "\n"); got != "value: "+strings.Repeat("x", 20) {
+		t.Errorf("expected final state on screen, got %q", got)
+	}
+}
+
+func TestPrompt_MaxFPSDefersRenders(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	p := NewPrompt(PromptOptions{
+		Input:  in,
+		Output: out,
+		MaxFPS: 10,
+		Render: func(p *Prompt) string { return "value: " + p.UserInputSnapshot() },
+	})
+
+	done := make(chan any, 1)
+
+	go func() { done <- p.Prompt(context.Background()) }()
+
+	time.Sleep(5 * time.Millisecond)
+	in.EmitKeypress("a", Key{Name: "a", Rune: 'a'})
+	time.Sleep(20 * time.Millisecond)
+
+	if got := screenOf(out); got != "value: " {
+		t.Errorf("expected render to be held back by the cap, got %q", got)
+	}
+
+	time.Sleep(120 * time.Millisecond)
+
+	if got := screenOf(out); got != "value: a" {
+		t.Errorf("expected deferred render, got %q", got)
+	}
+
+	in.EmitKeypress("b", Key{Name: "b", Rune: 'b'})
+	in.EmitKeypress("", Key{Name: "return"})
+	<-done
+
+	if got := strings.TrimSuffix(screenOf(out), "\n"); got != "value: ab" {
+		t.Errorf("expected final state rendered before finalize, got %q", got)
+	}
+}
+
+func TestFrameInterval(t *testing.T) {
+	SetMaxFPS(50)
+	defer SetMaxFPS(0)
+
+	if got := frameInterval(0); got != 20*time.Millisecond {
+		t.Errorf("expected global cap, got %v", got)
+	}
+
+	if got := frameInterval(100); got != 10*time.Millisecond {
+		t.Errorf("expected per-prompt cap, got %v", got)
+	}
+
+	if got := frameInterval(-1); got != 0 {
+		t.Errorf("expected negative fps to disable the cap, got %v", got)
+	}
+}
+
+func TestTerminalSize(t *testing.T) {
+	out := NewMockWritable()
+
+	if cols, rows := terminalSize(out); cols != 0 || rows != 0 {
+		t.Errorf("expected unknown size, got %dx%d", cols, rows)
+	}
+
+	if got := getColumns(out); got != 80 {
+		t.Errorf("expected 80 column fallback, got %d", got)
+	}
+
+	out.SetSize(100, 30)
+
+	// Color conversion wraps the writer; the size must still come through.
+	wrapped := &profileWriter{Writer: out, profile: ColorNone}
+	if cols, rows := terminalSize(wrapped); cols != 100 || rows != 30 {
+		t.Errorf("expected 100x30, got %dx%d", cols, rows)
+	}
+}
+
+func TestPrompt_RedrawsWhenWriterResizes(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	p := NewPrompt(PromptOptions{
+		Input:  in,
+		Output: out,
+		Render: func(p *Prompt) string {
+			cols, _ := p.TerminalSize()
+			return strings.Repeat("-", max(cols, 1))
+		},
+	})
+
+	done := make(chan any, 1)
+
+	go func() { done <- p.Prompt(context.Background()) }()
+
+	time.Sleep(time.Millisecond)
+	out.SetSize(10, 5)
+	time.Sleep(time.Millisecond)
+
+	if got := screenOf(out); got != strings.Repeat("-", 10) {
+		t.Errorf("expected frame sized to the writer, got %q", got)
+	}
+
+	in.EmitKeypress("", Key{Name: "return"})
+	<-done
+}
+
+func TestTerminalEventHandlersAreUnregistered(t *testing.T) {
+	out := NewMockWritable()
+
+	spinner := NewSpinner(SpinnerOptions{Output: out})
+	spinner.Start("spin")
+
+	progress := NewProgress(ProgressOptions{Output: out})
+	progress.Start("load")
+
+	stream := NewStream(StreamOptions{Output: out})
+	stream.Start("run")
+
+	if got := out.Listeners("resize"); got != 3 {
+		t.Fatalf("expected a resize handler per running utility, got %d", got)
+	}
+
+	spinner.Stop("done", 0)
+	progress.Stop("done", 0)
+	stream.Stop("done", 0)
+
+	in := NewMockReadable()
+	done := make(chan any, 1)
+
+	p := NewPrompt(PromptOptions{Input: in, Output: out, Render: func(*Prompt) string { return "q" }})
+
+	go func() { done <- p.Prompt(context.Background()) }()
+
+	time.Sleep(time.Millisecond)
+	in.EmitKeypress("", Key{Name: "return"})
+	<-done
+
+	for _, event := range []string{"resize", "continue"} {
+		if got := out.Listeners(event); got != 0 {
+			t.Errorf("expected no %s handlers once everything stopped, got %d", event, got)
+		}
+	}
+}
+
+// eventWriter is a Writer without Unsubscriber that keeps its handlers.
+type eventWriter struct {
+	plainWriter
+	handlers []func()
+}
+
+func (w *eventWriter) On(_ string, handler func()) { w.handlers = append(w.handlers, handler) }
+
+func TestOnTerminalEvents_DisablesHandlersWithoutUnsubscriber(t *testing.T) {
+	w := &eventWriter{}
+
+	var calls int
+
+	off := onTerminalEvents(w, func() { calls++ }, func() { calls++ })
+
+	for _, h := range w.handlers {
+		h()
+	}
+
+	off()
+
+	for _, h := range w.handlers {
+		h()
+	}
+
+	if calls != 2 {
+		t.Errorf("expected handlers to run only until off, got %d calls", calls)
+	}
+}
+
+// slowWriter is a MockWritable whose writes take a while, widening the
+// window in which unserialized redraws interleave.
+type slowWriter struct{ *MockWritable }
+
+func (w slowWriter) Write(p []byte) (int, error) {
+	time.Sleep(50 * time.Microsecond)
+	return w.MockWritable.Write(p)
+}
+
+func TestSpinnerAndProgress_ConcurrentRedrawsDoNotInterleave(t *testing.T) {
+	mock := NewMockWritable()
+	out := slowWriter{mock}
+
+	spinner := NewSpinner(SpinnerOptions{Output: out, Delay: time.Millisecond})
+	spinner.Start("spin")
+
+	var wg sync.WaitGroup
+
+	for range 20 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			out.Emit("resize")
+		}()
+	}
+
+	wg.Wait()
+	spinner.Stop("spun", 0)
+
+	progress := NewProgress(ProgressOptions{Output: out})
+	progress.Start("load")
+
+	for range 20 {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			out.Emit("resize")
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			progress.Advance(1, "")
+		}()
+	}
+
+	wg.Wait()
+	progress.Stop("loaded", 0)
+
+	screen := screenOf(mock)
+	if strings.Contains(screen, "spin") || strings.Contains(screen, "load\n") || strings.Count(screen, "\n") != 6 {
+		t.Errorf("expected only the two final messages on screen, got:\n%s", screen)
+	}
+}
@@ -0,0 +1,22 @@
+package tap
+
+import "github.com/yarlson/tap/internal/terminal"
+
+// Restore undoes every terminal mode tap has enabled and not yet turned off:
+// the hidden cursor, bracketed paste, modifyOtherKeys, raw mode and the
+// alternate screen. Prompts restore their own modes when they finish, and
+// tap restores everything on SIGINT, SIGTERM and panics in render functions
+// or key handlers. Apps call it before exiting, typically with
+// defer tap.Restore() in main, and from their own panic or signal handlers.
+// Prompts started afterwards set the terminal up again.
+func Restore() { terminal.Restore() }
+
+// trackMode registers undo to be written to the prompt's output on Restore
+// and when the process is stopped, and redo when it continues. It returns
+// the function that unregisters them.
+func (p *Prompt) trackMode(undo, redo string) (release func()) {
+	return terminal.TrackMode(
+		func() { _, _ = p.output.Write([]byte(undo)) },
+		func() { _, _ = p.output.Write([]byte(redo)) },
+	)
+}
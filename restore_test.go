@@ -0,0 +1,104 @@
+package tap
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRestore_UndoesActivePromptModes(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	done := make(chan string, 1)
+
+	go func() {
+		done <- Text(context.Background(), TextOptions{Message: "Name:", Input: in, Output: out})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+
+	n := len(out.GetFrames())
+	Restore()
+
+	got := strings.Join(out.GetFrames()[n:], "")
+	for _, want := range []string{CursorShow, bracketedPasteDisable} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q to be written on Restore, got %q", want, got)
+		}
+	}
+
+	in.EmitKeypress("", Key{Name: "return"})
+	<-done
+}
+
+func TestRestore_NothingAfterPromptFinishes(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	done := make(chan string, 1)
+
+	go func() {
+		done <- Text(context.Background(), TextOptions{Message: "Name:", Input: in, Output: out})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	in.EmitKeypress("", Key{Name: "return"})
+	<-done
+
+	n := len(out.GetFrames())
+	Restore()
+
+	if got := out.GetFrames()[n:]; len(got) != 0 {
+		t.Errorf("expected nothing to restore, got %q", got)
+	}
+}
+
+func TestPromptsReleaseTerminalAfterRoundTrip(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	var opened, raw int
+
+	orig := openTerminal
+	openTerminal = func() (Reader, Writer, func(), error) {
+		opened++
+		raw++
+
+		return in, out, func() { raw-- }, nil
+	}
+
+	defer func() { openTerminal = orig }()
+
+	done := make(chan struct{})
+
+	go func() {
+		_ = Text(context.Background(), TextOptions{Message: "Name:"})
+		done <- struct{}{}
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	in.EmitKeypress("", Key{Name: "return"})
+	<-done
+
+	if raw != 0 {
+		t.Fatalf("expected the terminal to be released after Text, %d uses left", raw)
+	}
+
+	go func() {
+		_ = Select(context.Background(), SelectOptions[string]{
+			Message: "Pick:",
+			Options: []SelectOption[string]{{Value: "a"}, {Value: "b"}},
+		})
+		done <- struct{}{}
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	in.EmitKeypress("", Key{Name: "return"})
+	<-done
+
+	if opened != 2 || raw != 0 {
+		t.Fatalf("expected two opens and no uses left after Select, got %d opens and %d uses", opened, raw)
+	}
+}
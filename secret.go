@@ -0,0 +1,320 @@
+package tap
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"unicode/utf8"
+)
+
+// redacted is printed in place of secret contents.
+const redacted = "[redacted]"
+
+// Secret holds sensitive input in a mutable byte buffer that can be wiped.
+// The plaintext is never converted to a string by tap; fmt verbs print a
+// redaction marker instead of the contents. Call Zero once the value is no
+// longer needed.
+type Secret struct {
+	b []byte
+}
+
+// Bytes returns the underlying buffer. It is valid until Zero is called and
+// must not be retained beyond that.
+func (s *Secret) Bytes() []byte {
+	if s == nil {
+		return nil
+	}
+
+	return s.b
+}
+
+// Len returns the length of the secret in bytes.
+func (s *Secret) Len() int {
+	if s == nil {
+		return 0
+	}
+
+	return len(s.b)
+}
+
+// Zero overwrites the buffer with zeros and releases it.
+func (s *Secret) Zero() {
+	if s == nil {
+		return
+	}
+
+	clear(s.b)
+	s.b = nil
+}
+
+// String implements fmt.Stringer without revealing the contents.
+func (s *Secret) String() string { return redacted }
+
+// Format implements fmt.Formatter so that every verb, including %#v, is redacted.
+func (s *Secret) Format(f fmt.State, _ rune) { _, _ = io.WriteString(f, redacted) }
+
+// newSecret encodes runes into a freshly allocated buffer sized exactly to fit,
+// so no intermediate copies are left behind.
+func newSecret(runes []rune) *Secret {
+	n := 0
+	for _, r := range runes {
+		n += utf8.RuneLen(r)
+	}
+
+	b := make([]byte, 0, n)
+	for _, r := range runes {
+		b = utf8.AppendRune(b, r)
+	}
+
+	return &Secret{b: b}
+}
+
+// insertSecretRune inserts r at i. When the buffer must grow, the old backing
+// array is wiped so the plaintext does not linger in released memory.
+func insertSecretRune(buf []rune, i int, r rune) []rune {
+	if len(buf) == cap(buf) {
+		grown := make([]rune, len(buf), 2*cap(buf)+16)
+		copy(grown, buf)
+		clear(buf)
+		buf = grown
+	}
+
+	return slices.Insert(buf, i, r)
+}
+
+// PasswordBytes is like Password but returns the input as a Secret whose
+// buffer never becomes an immutable string. The typed value is kept out of
+// prompt state, snapshots and rendered frames; only its length is rendered.
+// Returns nil on cancel. The caller owns the Secret and should Zero it.
+//
+// ValidateSecret is used in place of Validate, and ConfirmMessage is compared
+// in constant time. AllowReveal and Strength are not supported since both
+// require the plaintext as a string.
+func PasswordBytes(ctx context.Context, opts PasswordOptions) *Secret {
+	if opts.Input != nil && opts.Output != nil {
+		return passwordBytes(ctx, opts)
+	}
+
+	return runWithTerminal(func(in Reader, out Writer) *Secret {
+		if opts.Input == nil {
+			opts.Input = in
+		}
+
+		if opts.Output == nil {
+			opts.Output = out
+		}
+
+		return passwordBytes(ctx, opts)
+	})
+}
+
+// passwordBytes implements PasswordBytes, including the optional confirmation entry.
+func passwordBytes(ctx context.Context, opts PasswordOptions) *Secret {
+	notice := ""
+
+	for {
+		first := passwordBytesEntry(ctx, opts, opts.Message, opts.ValidateSecret, notice)
+		if first == nil || opts.ConfirmMessage == "" {
+			return first
+		}
+
+		second := passwordBytesEntry(ctx, opts, opts.ConfirmMessage, nil, "")
+		if second == nil {
+			first.Zero()
+			return nil
+		}
+
+		match := subtle.ConstantTimeCompare(second.Bytes(), first.Bytes()) == 1
+
+		first.Zero()
+
+		if match {
+			return second
+		}
+
+		second.Zero()
+
+		// The typo may be in either entry, so both are entered again
+		notice = opts.MismatchMessage
+		if notice == "" {
+			notice = resolveLocale(opts.Locale).PasswordMismatch
+		}
+	}
+}
+
+// passwordBytesEntry runs a single masked entry backed by a local rune buffer
+// (track=false, so the prompt never sees the input). A non-empty notice is
+// shown as an error until the first key.
+func passwordBytesEntry(ctx context.Context, opts PasswordOptions, message string, validateFn func([]byte) error, notice string) *Secret {
+	th := resolveTheme(opts.Theme)
+
+	mask := opts.Mask
+	if mask == 0 {
+		mask = th.PasswordMask
+	}
+
+	if mask == 0 {
+		mask = defaultPasswordMask
+	}
+
+	var (
+		buf    []rune
+		cur    int
+		length int // rune count rendered after the buffer is wiped
+		result *Secret
+	)
+
+	wipe := func() {
+		clear(buf)
+		buf = buf[:0]
+		cur = 0
+	}
+
+	p := NewPromptWithTracking(PromptOptions{
+		Input:  opts.Input,
+		Output: opts.Output,
+		Render: func(p *Prompt) string {
+			s := p.StateSnapshot()
+
+			title := th.muted(th.Bar) + "\n" + th.Symbol(s) + "  " + message + "\n"
+
+			input := ""
+			if opts.Silent {
+				if s == StateActive || s == StateInitial {
+					input = inverse(" ")
+				}
+			} else {
+				input = renderMaskedWithCursor(len(buf), cur, s, mask)
+			}
+
+			masked := ""
+			if !opts.Silent && length > 0 {
+				masked = string(slices.Repeat([]rune{mask}, length))
+			}
+
+			switch s {
+			case StateError:
+				errMsg := p.ErrorSnapshot()
+				return title + th.warning(th.Bar) + "  " + input + "\n" + th.warning(th.BarEnd) + "  " + th.warning(errMsg)
+
+			case StateSubmit:
+				if masked == "" {
+					return title + th.muted(th.Bar)
+				}
+
+				return title + th.muted(th.Bar) + "  " + dim(masked)
+
+			case StateCancel:
+				if masked == "" {
+					return title + th.muted(th.Bar)
+				}
+
+				return title + th.muted(th.Bar) + "  " + strikethrough(dim(masked)) + "\n" + th.muted(th.Bar)
+
+			default:
+				if notice != "" {
+					title = th.muted(th.Bar) + "\n" + th.Symbol(StateError) + "  " + message + "\n"
+					return title + th.warning(th.Bar) + "  " + input + "\n" + th.warning(th.BarEnd) + "  " + th.warning(notice)
+				}
+
+				return title + th.active(th.Bar) + "  " + input + "\n" + th.active(th.BarEnd)
+			}
+		},
+	}, false)
+
+	p.On("key", func(_ string, key Key) {
+		notice = ""
+
+		switch key.Name {
+		case "left":
+			if cur > 0 {
+				cur--
+			}
+		case "right":
+			if cur < len(buf) {
+				cur++
+			}
+		case "home":
+			cur = 0
+		case "end":
+			cur = len(buf)
+		case "backspace":
+			if cur > 0 {
+				buf = slices.Delete(buf, cur-1, cur)
+				cur--
+			}
+		case "delete":
+			if cur < len(buf) {
+				buf = slices.Delete(buf, cur, cur+1)
+			}
+		case "paste":
+			content, err := sanitizePaste(key.Content, opts.PasteNewlines, resolveLocale(opts.Locale))
+			if err != nil {
+				p.cur.Error = err.Error()
+				p.cur.State = StateError
+
+				break
+			}
+
+			for _, r := range content {
+				buf = insertSecretRune(buf, cur, r)
+				cur++
+			}
+		case "return":
+			secret := newSecret(buf)
+			if len(buf) == 0 && opts.DefaultValue != "" {
+				secret = &Secret{b: []byte(opts.DefaultValue)}
+			}
+
+			if validateFn != nil {
+				if err := validateFn(secret.Bytes()); err != nil {
+					secret.Zero()
+
+					errMsg := err.Error()
+					e := &ValidationError{}
+					if errors.As(err, &e) {
+						errMsg = e.Message
+					}
+
+					p.cur.Error = errMsg
+					p.cur.State = StateError
+
+					return
+				}
+			}
+
+			result = secret
+			length = len(buf)
+			wipe()
+
+			// Mark submission without exposing the value to prompt state.
+			p.cur.Value = true
+			p.cur.State = StateSubmit
+
+			return
+		default:
+			if !key.Ctrl && key.Rune >= 32 && key.Rune <= 126 {
+				buf = insertSecretRune(buf, cur, key.Rune)
+				cur++
+			}
+		}
+
+		length = len(buf)
+	})
+
+	p.On("finalize", func() {
+		wipe()
+	})
+
+	enableBracketedPaste(p, opts.Output)
+
+	if p.Prompt(ctx) == nil {
+		result.Zero()
+		return nil
+	}
+
+	return result
+}
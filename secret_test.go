@@ -0,0 +1,226 @@
+package tap
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPasswordBytes_ReturnsSecretAndKeepsItOutOfState(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	done := make(chan *Secret, 1)
+
+	go func() {
+		done <- PasswordBytes(context.Background(), PasswordOptions{
+			Message: "Token:",
+			Input:   in,
+			Output:  out,
+		})
+	}()
+
+	time.Sleep(time.Millisecond)
+	in.EmitKeypress("s", Key{Name: "s", Rune: 's'})
+	in.EmitKeypress("3", Key{Name: "3", Rune: '3'})
+	in.EmitKeypress("c", Key{Name: "c", Rune: 'c'})
+	in.EmitKeypress("", Key{Name: "return"})
+
+	secret := <-done
+	if secret == nil {
+		t.Fatal("expected a secret")
+	}
+
+	if !bytes.Equal(secret.Bytes(), []byte("s3c")) {
+		t.Fatalf("expected 's3c', got %q", secret.Bytes())
+	}
+
+	for _, f := range out.GetFrames() {
+		if strings.Contains(f, "s3c") {
+			t.Fatalf("plaintext leaked into frame %q", f)
+		}
+	}
+
+	buf := secret.Bytes()
+	secret.Zero()
+
+	if !bytes.Equal(buf, []byte{0, 0, 0}) {
+		t.Errorf("expected buffer to be zeroed, got %v", buf)
+	}
+
+	if secret.Len() != 0 {
+		t.Errorf("expected empty secret after Zero, got len %d", secret.Len())
+	}
+}
+
+func TestPasswordBytes_CancelReturnsNil(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	done := make(chan *Secret, 1)
+
+	go func() {
+		done <- PasswordBytes(context.Background(), PasswordOptions{
+			Message: "Token:",
+			Input:   in,
+			Output:  out,
+		})
+	}()
+
+	time.Sleep(time.Millisecond)
+	in.EmitKeypress("x", Key{Name: "x", Rune: 'x'})
+	in.EmitKeypress("", Key{Name: "escape"})
+
+	if got := <-done; got != nil {
+		t.Fatalf("expected nil on cancel, got %v", got)
+	}
+}
+
+func TestPasswordBytes_ValidateSecret(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	done := make(chan *Secret, 1)
+
+	go func() {
+		done <- PasswordBytes(context.Background(), PasswordOptions{
+			Message: "Token:",
+			ValidateSecret: func(b []byte) error {
+				if len(b) < 2 {
+					return errors.New("too short")
+				}
+
+				return nil
+			},
+			Input:  in,
+			Output: out,
+		})
+	}()
+
+	time.Sleep(time.Millisecond)
+	in.EmitKeypress("a", Key{Name: "a", Rune: 'a'})
+	in.EmitKeypress("", Key{Name: "return"})
+	time.Sleep(10 * time.Millisecond)
+
+	frames := out.GetFrames()
+	if !strings.Contains(frames[len(frames)-1], "too short") {
+		t.Fatalf("expected validation error, got %q", frames[len(frames)-1])
+	}
+
+	in.EmitKeypress("b", Key{Name: "b", Rune: 'b'})
+	in.EmitKeypress("", Key{Name: "return"})
+
+	if got := <-done; !bytes.Equal(got.Bytes(), []byte("ab")) {
+		t.Fatalf("expected 'ab', got %q", got.Bytes())
+	}
+}
+
+func TestPasswordBytes_Confirm(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	done := make(chan *Secret, 1)
+
+	go func() {
+		done <- PasswordBytes(context.Background(), PasswordOptions{
+			Message:        "Password:",
+			ConfirmMessage: "Repeat:",
+			Input:          in,
+			Output:         out,
+		})
+	}()
+
+	time.Sleep(time.Millisecond)
+	in.EmitKeypress("a", Key{Name: "a", Rune: 'a'})
+	in.EmitKeypress("", Key{Name: "return"})
+	time.Sleep(5 * time.Millisecond)
+	in.EmitKeypress("b", Key{Name: "b", Rune: 'b'})
+	in.EmitKeypress("", Key{Name: "return"})
+	time.Sleep(10 * time.Millisecond)
+
+	// A mismatch starts over at the first entry
+	frames := out.GetFrames()
+	if last := frames[len(frames)-1]; !strings.Contains(last, "Passwords do not match") || !strings.Contains(last, "Password:") {
+		t.Fatalf("expected the first entry with the mismatch error, got %q", last)
+	}
+
+	in.EmitKeypress("c", Key{Name: "c", Rune: 'c'})
+	in.EmitKeypress("", Key{Name: "return"})
+	time.Sleep(5 * time.Millisecond)
+	in.EmitKeypress("c", Key{Name: "c", Rune: 'c'})
+	in.EmitKeypress("", Key{Name: "return"})
+
+	if got := <-done; !bytes.Equal(got.Bytes(), []byte("c")) {
+		t.Fatalf("expected 'c', got %q", got.Bytes())
+	}
+}
+
+func TestPasswordBytes_KeepsLetterCase(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	done := make(chan *Secret, 1)
+
+	go func() {
+		done <- PasswordBytes(context.Background(), PasswordOptions{
+			Message: "Token:",
+			Input:   in,
+			Output:  out,
+		})
+	}()
+
+	time.Sleep(time.Millisecond)
+	// The prompt emits the key's character lowercased; the rune keeps its case
+	in.EmitKeypress("A", Key{Name: "a", Rune: 'A', Shift: true})
+	in.EmitKeypress("b", Key{Name: "b", Rune: 'b'})
+	in.EmitKeypress("C", Key{Name: "c", Rune: 'C', Shift: true})
+	in.EmitKeypress("", Key{Name: "return"})
+
+	secret := <-done
+	defer secret.Zero()
+
+	if !bytes.Equal(secret.Bytes(), []byte("AbC")) {
+		t.Fatalf("expected 'AbC', got %q", secret.Bytes())
+	}
+}
+
+func TestSecret_FormatIsRedacted(t *testing.T) {
+	s := &Secret{b: []byte("hunter2")}
+
+	for _, verb := range []string{"%v", "%+v", "%#v", "%s", "%q", "%x"} {
+		if got := fmt.Sprintf(verb, s); strings.Contains(got, "hunter2") || got != "[redacted]" {
+			t.Errorf("%s: got %q", verb, got)
+		}
+	}
+}
+
+func TestPasswordBytes_Paste(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	done := make(chan *Secret, 1)
+
+	go func() {
+		done <- PasswordBytes(context.Background(), PasswordOptions{
+			Message: "Token:",
+			Input:   in,
+			Output:  out,
+		})
+	}()
+
+	time.Sleep(time.Millisecond)
+	in.EmitKeypress("x", Key{Name: "x", Rune: 'x'})
+	in.EmitPaste("secret\n")
+	in.EmitKeypress("", Key{Name: "return"})
+
+	secret := <-done
+	defer secret.Zero()
+
+	if string(secret.Bytes()) != "xsecret" {
+		t.Fatalf("expected pasted content, got %q", secret.Bytes())
+	}
+}
@@ -9,6 +9,8 @@ import (
 // styledSelectState holds the state for a styled select prompt.
 type styledSelectState[T any] struct {
 	cursor  int
+	top     int // first option shown when the list scrolls
+	height  int // number of options shown
 	options []SelectOption[T]
 }
 
@@ -58,10 +60,22 @@ func selectInternal[T any](ctx context.Context, opts SelectOptions[T]) T {
 	}
 
 	styledPrompt := NewPromptWithTracking(PromptOptions{
-		Input:  opts.Input,
-		Output: opts.Output,
+		Input:     opts.Input,
+		Output:    opts.Output,
+		AltScreen: opts.AltScreen,
+		Mouse:     opts.Mouse,
 		Render: func(p *Prompt) string {
-			return renderStyledSelect(p, opts, state.options, state.cursor)
+			return renderStyledSelect(p, opts, state)
+		},
+		Announce: func(_ *Prompt) string {
+			option := state.options[state.cursor]
+
+			label := option.Label
+			if label == "" {
+				label = fmt.Sprintf("%v", option.Value)
+			}
+
+			return fmt.Sprintf(resolveLocale(opts.Locale).SelectedAnnouncement, label, state.cursor+1, len(state.options))
 		},
 		InitialValue: initialValue,
 	}, false)
@@ -88,6 +102,23 @@ func selectInternal[T any](ctx context.Context, opts SelectOptions[T]) T {
 		styledPrompt.SetImmediateValue(newValue)
 	})
 
+	// Clicking an option selects and submits it
+	styledPrompt.On("key", func(_ string, key Key) {
+		if key.Name != "click" {
+			return
+		}
+
+		idx, ok := listItemAt(styledPrompt, key.Y, titleLines(opts.Message), state.top, state.height)
+		if !ok {
+			return
+		}
+
+		state.cursor = idx
+		styledPrompt.SetImmediateValue(state.options[idx].Value)
+		styledPrompt.cur.Value = state.options[idx].Value
+		styledPrompt.cur.State = StateSubmit
+	})
+
 	v := styledPrompt.Prompt(ctx)
 	if t, ok := v.(T); ok {
 		return t
@@ -116,11 +147,13 @@ func isEqual[T any](a, b T) bool {
 	return fmt.Sprintf("%v", a) == fmt.Sprintf("%v", b)
 }
 
-func renderStyledSelect[T any](p *Prompt, opts SelectOptions[T], coreOptions []SelectOption[T], cursor int) string {
+func renderStyledSelect[T any](p *Prompt, opts SelectOptions[T], st *styledSelectState[T]) string {
+	th := resolveTheme(opts.Theme)
+	coreOptions, cursor := st.options, st.cursor
 	state := p.StateSnapshot()
 
 	// Build title
-	title := fmt.Sprintf("%s\n%s  %s\n", gray(Bar), Symbol(state), opts.Message)
+	title := fmt.Sprintf("%s\n%s  %s\n", th.muted(th.Bar), th.Symbol(state), opts.Message)
 
 	switch state {
 	case StateSubmit:
@@ -131,31 +164,36 @@ func renderStyledSelect[T any](p *Prompt, opts SelectOptions[T], coreOptions []S
 			label = fmt.Sprintf("%v", selected.Value)
 		}
 
-		return fmt.Sprintf("%s%s  %s", title, gray(Bar), dim(label))
+		return fmt.Sprintf("%s%s  %s", title, th.muted(th.Bar), dim(label))
 
 	default:
 		var lines []string
 
-		for i, option := range coreOptions {
+		// The title, the closing bar and the empty last line take four rows
+		top, height := listWindow(p, st.top, cursor, len(coreOptions), 4)
+		st.top, st.height = top, height
+
+		for i := top; i < top+height; i++ {
+			option := coreOptions[i]
+			bar := th.active(listBar(th, i, top, height, len(coreOptions)))
+
 			label := option.Label
 			if label == "" {
 				label = fmt.Sprintf("%v", option.Value)
 			}
 
 			if i == cursor {
-				line := fmt.Sprintf("%s %s", green(RadioActive), label)
+				line := fmt.Sprintf("%s  %s %s", bar, th.success(th.RadioActive), label)
 				if option.Hint != "" {
 					line += fmt.Sprintf(" %s", dim(fmt.Sprintf("(%s)", option.Hint)))
 				}
 
 				lines = append(lines, line)
 			} else {
-				lines = append(lines, fmt.Sprintf("%s %s", dim(RadioInactive), dim(label)))
+				lines = append(lines, fmt.Sprintf("%s  %s %s", bar, dim(th.RadioInactive), dim(label)))
 			}
 		}
 
-		optionsText := strings.Join(lines, fmt.Sprintf("\n%s  ", cyan(Bar)))
-
-		return fmt.Sprintf("%s%s  %s\n%s\n", title, cyan(Bar), optionsText, cyan(BarEnd))
+		return fmt.Sprintf("%s%s\n%s\n", title, strings.Join(lines, "\n"), th.active(th.BarEnd))
 	}
 }
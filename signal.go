@@ -0,0 +1,98 @@
+package tap
+
+import (
+	"os"
+	"sync"
+
+	"github.com/yarlson/tap/internal/terminal"
+)
+
+// SignalMode selects how SIGINT and SIGTERM are handled while tap has the
+// terminal open.
+type SignalMode int
+
+const (
+	// SignalExit restores the terminal and exits with status 1. This is
+	// the default.
+	SignalExit SignalMode = iota
+	// SignalCancel cancels the active prompts, which return as if the user
+	// pressed Ctrl+C, and runs the handler set with OnSignal. The process
+	// keeps running, so deferred cleanup runs as usual.
+	SignalCancel
+	// SignalIgnore leaves the signals to the app, for example to
+	// signal.NotifyContext; prompts are then cancelled through their context.
+	SignalIgnore
+)
+
+var (
+	signalMu sync.Mutex
+	onSignal func(os.Signal)
+
+	activeMu      sync.Mutex
+	activePrompts = map[*Prompt]struct{}{}
+)
+
+// SetSignalMode sets how SIGINT and SIGTERM are handled.
+func SetSignalMode(mode SignalMode) {
+	switch mode {
+	case SignalCancel:
+		terminal.SetSignals(true, cancelOnSignal)
+	case SignalIgnore:
+		terminal.SetSignals(false, nil)
+	default:
+		terminal.SetSignals(true, nil)
+	}
+}
+
+// OnSignal sets fn to run with each SIGINT or SIGTERM in SignalCancel mode,
+// after the active prompts are cancelled. Pass nil to remove it.
+func OnSignal(fn func(os.Signal)) {
+	signalMu.Lock()
+	defer signalMu.Unlock()
+
+	onSignal = fn
+}
+
+// cancelOnSignal cancels the active prompts and runs the OnSignal handler.
+func cancelOnSignal(sig os.Signal) {
+	cancelActivePrompts()
+
+	signalMu.Lock()
+	fn := onSignal
+	signalMu.Unlock()
+
+	if fn != nil {
+		fn(sig)
+	}
+}
+
+// setActive records whether p is running, so a signal can cancel it.
+func setActive(p *Prompt, active bool) {
+	activeMu.Lock()
+	defer activeMu.Unlock()
+
+	if active {
+		activePrompts[p] = struct{}{}
+	} else {
+		delete(activePrompts, p)
+	}
+}
+
+// cancelActivePrompts cancels every running prompt.
+func cancelActivePrompts() {
+	activeMu.Lock()
+	prompts := make([]*Prompt, 0, len(activePrompts))
+
+	for p := range activePrompts {
+		prompts = append(prompts, p)
+	}
+
+	activeMu.Unlock()
+
+	for _, p := range prompts {
+		select {
+		case p.evCh <- func(s *promptState) { p.handleAbort(s) }:
+		case <-p.stopped:
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package tap
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSignalCancel_CancelsActivePrompts(t *testing.T) {
+	SetSignalMode(SignalCancel)
+	defer SetSignalMode(SignalExit)
+
+	received := make(chan os.Signal, 1)
+
+	OnSignal(func(sig os.Signal) { received <- sig })
+	defer OnSignal(nil)
+
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	done := make(chan bool, 1)
+
+	go func() {
+		done <- Confirm(context.Background(), ConfirmOptions{Message: "Continue?", InitialValue: true, Input: in, Output: out})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	cancelOnSignal(os.Interrupt)
+
+	select {
+	case got := <-done:
+		if got {
+			t.Errorf("expected the cancelled prompt to return false")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the prompt to be cancelled")
+	}
+
+	if sig := <-received; sig != os.Interrupt {
+		t.Errorf("expected the handler to receive the signal, got %v", sig)
+	}
+}
@@ -16,6 +16,7 @@ type SpinnerOptions struct {
 	Output        Writer
 	CancelMessage string
 	ErrorMessage  string
+	Theme         *Theme // overrides the global theme
 }
 
 // Spinner represents an animated spinner.
@@ -24,6 +25,12 @@ type Spinner struct {
 	frames    []string
 	delay     time.Duration
 	output    Writer
+	theme     *Theme
+
+	// renderMu serializes drawing, which runs on the ticker, on signal
+	// goroutines and in the caller's: it is held from reading lastFrameLines
+	// until the new frame is written.
+	renderMu sync.Mutex
 
 	mu              sync.RWMutex
 	isActive        bool
@@ -38,6 +45,12 @@ type Spinner struct {
 	stopCh chan struct{}
 
 	lastFrameLines int
+
+	offOutput func() // unregisters the resize and continue handlers
+
+	// Accessible-mode status bookkeeping.
+	statusMsg string
+	statusAt  time.Time
 }
 
 func clearLines(out Writer, lines int) {
@@ -73,7 +86,11 @@ func newSpinner(opts SpinnerOptions) *Spinner {
 
 	frames := opts.Frames
 	if len(frames) == 0 {
-		frames = []string{"◒", "◐", "◓", "◑"}
+		frames = resolveTheme(opts.Theme).SpinnerFrames
+	}
+
+	if len(frames) == 0 {
+		frames = DefaultTheme().SpinnerFrames
 	}
 
 	delay := opts.Delay
@@ -81,13 +98,16 @@ func newSpinner(opts SpinnerOptions) *Spinner {
 		delay = 80 * time.Millisecond
 	}
 
-	return &Spinner{
+	s := &Spinner{
 		indicator: indicator,
 		frames:    frames,
 		delay:     delay,
-		output:    opts.Output,
+		output:    withColorProfile(opts.Output),
+		theme:     opts.Theme,
 		stopCh:    make(chan struct{}),
 	}
+
+	return s
 }
 
 // Start begins the spinner animation.
@@ -100,6 +120,7 @@ func (s *Spinner) Start(msg string) {
 	}
 
 	s.isActive = true
+	s.statusMsg = ""
 	s.message = removeTrailingDots(msg)
 	s.frameIndex = 0
 	s.dotTick = 0
@@ -107,6 +128,13 @@ func (s *Spinner) Start(msg string) {
 	lastLines := s.lastFrameLines
 	s.lastFrameLines = 0
 	s.lastFrameLength = 0
+
+	// render clears the previous frame with the rows it took when drawn,
+	// then draws at the new width.
+	if s.output != nil {
+		s.offOutput = onTerminalEvents(s.output, s.render, s.handleContinue)
+	}
+
 	s.mu.Unlock()
 
 	s.ticker = time.NewTicker(s.delay)
@@ -115,9 +143,10 @@ func (s *Spinner) Start(msg string) {
 	// OSC 9;4 indeterminate spinner
 	oscSpin(s.output)
 
+	s.renderMu.Lock()
 	clearLines(s.output, lastLines)
-
-	s.render()
+	s.draw()
+	s.renderMu.Unlock()
 }
 
 // Message updates the spinner message for next frame.
@@ -143,7 +172,12 @@ func (s *Spinner) Stop(msg string, code int, opts ...StopOptions) {
 	currentMsg := s.message
 	start := s.startTime
 	indicator := s.indicator
-	lastLines := s.lastFrameLines
+
+	if s.offOutput != nil {
+		s.offOutput()
+		s.offOutput = nil
+	}
+
 	s.mu.Unlock()
 
 	if s.ticker != nil {
@@ -154,20 +188,30 @@ func (s *Spinner) Stop(msg string, code int, opts ...StopOptions) {
 
 	oscClear(s.output)
 
+	// Wait for a frame being drawn, then clear it
+	s.renderMu.Lock()
+	defer s.renderMu.Unlock()
+
+	s.mu.RLock()
+	lastLines := s.lastFrameLines
+	s.mu.RUnlock()
+
 	if s.output != nil {
 		if lastLines > 0 {
 			clearLines(s.output, lastLines)
 		}
 
+		th := resolveTheme(s.theme)
+
 		var symbol string
 
 		switch code {
 		case 0:
-			symbol = green(StepSubmit)
+			symbol = th.success(th.StepSubmit)
 		case 1:
-			symbol = red(StepCancel)
+			symbol = th.danger(th.StepCancel)
 		default:
-			symbol = red(StepError)
+			symbol = th.danger(th.StepError)
 		}
 
 		finalMsg := msg
@@ -187,19 +231,23 @@ func (s *Spinner) Stop(msg string, code int, opts ...StopOptions) {
 		var final string
 		if hint != "" {
 			final = strings.Join([]string{
-				gray(Bar),
+				th.muted(th.Bar),
 				fmt.Sprintf("%s  %s", symbol, finalMsg),
-				fmt.Sprintf("%s  %s", gray(Bar), gray(hint)),
+				fmt.Sprintf("%s  %s", th.muted(th.Bar), th.muted(hint)),
 			}, "\n") + "\n"
 		} else {
 			final = strings.Join([]string{
-				gray(Bar),
+				th.muted(th.Bar),
 				fmt.Sprintf("%s  %s", symbol, finalMsg),
-				gray(Bar),
+				th.muted(th.Bar),
 			}, "\n") + "\n"
 		}
 
-		_, _ = s.output.Write([]byte(final))
+		if accessible.Load() {
+			writeLine(s.output, plainFrame(final))
+		} else {
+			_, _ = s.output.Write([]byte(final))
+		}
 	}
 
 	s.mu.Lock()
@@ -227,11 +275,35 @@ func (s *Spinner) animate() {
 	}
 }
 
+// handleContinue draws the spinner afresh after the process continues from a
+// stop, below whatever the shell printed meanwhile.
+func (s *Spinner) handleContinue() {
+	s.renderMu.Lock()
+	defer s.renderMu.Unlock()
+
+	s.mu.Lock()
+	s.lastFrameLines = 0
+	s.mu.Unlock()
+
+	s.draw()
+}
+
+// render draws the next frame in place of the last one.
 func (s *Spinner) render() {
+	s.renderMu.Lock()
+	defer s.renderMu.Unlock()
+
+	s.draw()
+}
+
+// draw draws the next frame; the caller holds renderMu.
+func (s *Spinner) draw() {
 	if s.output == nil {
 		return
 	}
 
+	th := resolveTheme(s.theme)
+
 	s.mu.RLock()
 	msg := s.message
 	frame := s.frames[s.frameIndex]
@@ -245,6 +317,11 @@ func (s *Spinner) render() {
 		return
 	}
 
+	if accessible.Load() {
+		s.renderStatus(msg, start)
+		return
+	}
+
 	var displayMsg string
 	if indicator == "timer" {
 		displayMsg = fmt.Sprintf("%s %s", msg, formatTimer(start))
@@ -254,11 +331,11 @@ func (s *Spinner) render() {
 	}
 
 	content := strings.Join([]string{
-		gray(Bar),
-		fmt.Sprintf("%s  %s", cyan(frame), displayMsg),
-		gray(Bar),
+		th.muted(th.Bar),
+		fmt.Sprintf("%s  %s", th.active(frame), displayMsg),
+		th.muted(th.Bar),
 	}, "\n")
-	lineCount := strings.Count(content, "\n") + 1
+	lineCount := countPhysicalLines(content, getColumns(s.output))
 
 	if lastLines > 0 {
 		clearLines(s.output, lastLines)
@@ -274,6 +351,31 @@ func (s *Spinner) render() {
 	s.mu.Unlock()
 }
 
+// renderStatus prints the message as a plain status line in accessible mode,
+// when it changes and every accessibleInterval while it stays the same.
+func (s *Spinner) renderStatus(msg string, start time.Time) {
+	s.mu.Lock()
+
+	due := msg != s.statusMsg || time.Since(s.statusAt) >= accessibleInterval
+	if due {
+		s.statusMsg = msg
+		s.statusAt = time.Now()
+	}
+
+	s.mu.Unlock()
+
+	if !due {
+		return
+	}
+
+	status := msg + "..."
+	if time.Since(start) >= time.Second {
+		status += " " + formatTimer(start)
+	}
+
+	writeLine(s.output, status)
+}
+
 func (s *Spinner) currentDotCount() int {
 	dots := s.dotTick / 8
 	if dots > 3 {
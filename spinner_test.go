@@ -134,3 +134,36 @@ func TestSpinner_OSC94Signals(t *testing.T) {
 	frames3 := strings.Join(out3.GetFrames(), "")
 	assert.Contains(t, frames3, "\x1b]9;4;0\x1b\\")
 }
+
+func TestSpinner_ResizeRedraws(t *testing.T) {
+	out := NewMockWritable()
+
+	s := NewSpinner(SpinnerOptions{Output: out, Delay: time.Hour})
+	s.Start("Loading")
+	time.Sleep(time.Millisecond)
+
+	out.Emit("resize")
+
+	got := screenOf(out)
+	assert.Contains(t, got, "Loading")
+	assert.Equal(t, 2, strings.Count(got, "\n"), "expected a single redrawn frame")
+
+	s.Stop("Done", 0)
+}
+
+func TestSpinner_RedrawsAfterContinue(t *testing.T) {
+	out := NewMockWritable()
+
+	s := NewSpinner(SpinnerOptions{Output: out, Delay: time.Hour})
+	s.Start("Loading")
+	time.Sleep(time.Millisecond)
+
+	n := len(out.GetFrames())
+	out.Emit("continue")
+
+	redraw := strings.Join(out.GetFrames()[n:], "")
+	assert.Contains(t, redraw, "Loading")
+	assert.NotContains(t, redraw, "\033[1A", "expected a fresh frame without clearing")
+
+	s.Stop("Done", 0)
+}
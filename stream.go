@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"strings"
 	"sync"
 	"time"
 )
@@ -13,6 +14,8 @@ type StreamOptions struct {
 	Output Writer
 	// If true, show elapsed time on finalize line
 	ShowTimer bool
+	// Theme overrides the global theme
+	Theme *Theme
 }
 
 // Stream renders a live stream area with clack-like styling
@@ -25,17 +28,20 @@ type Stream struct {
 	start time.Time
 	opts  StreamOptions
 	title string
+	// rows is the number of physical rows from the title to the last line,
+	// measured at the width they were drawn.
+	rows int
+	// offOutput unregisters the resize and continue handlers.
+	offOutput func()
 }
 
 // NewStream creates a Stream.
 func NewStream(opts StreamOptions) *Stream {
-	if opts.Output != nil {
-		return &Stream{out: opts.Output, opts: opts}
+	if opts.Output == nil {
+		opts.Output = resolveWriter()
 	}
 
-	opts.Output = resolveWriter()
-
-	return &Stream{out: opts.Output, opts: opts}
+	return &Stream{out: withColorProfile(opts.Output), opts: opts}
 }
 
 // Start prints the header and prepares to receive lines.
@@ -51,9 +57,22 @@ func (s *Stream) Start(message string) {
 	s.start = time.Now()
 
 	s.title = message
+	s.lines = nil
+	s.rows = 0
+
 	if s.out != nil {
-		header := fmt.Sprintf("%s\n%s  %s\n", gray(Bar), Symbol(StateActive), message)
-		_, _ = s.out.Write([]byte(header))
+		s.offOutput = onTerminalEvents(s.out, func() { s.repaint(true) }, func() { s.repaint(false) })
+
+		th := resolveTheme(s.opts.Theme)
+		title := fmt.Sprintf("%s  %s", th.Symbol(StateActive), message)
+		header := th.muted(th.Bar) + "\n" + title + "\n"
+
+		if accessible.Load() {
+			writeLine(s.out, plainFrame(header))
+		} else {
+			_, _ = s.out.Write([]byte(header))
+			s.rows = countPhysicalLines(title, getColumns(s.out))
+		}
 	}
 }
 
@@ -66,9 +85,53 @@ func (s *Stream) WriteLine(line string) {
 		return
 	}
 
-	content := fmt.Sprintf("%s  %s\n", cyan(Bar), line)
-	_, _ = s.out.Write([]byte(content))
+	th := resolveTheme(s.opts.Theme)
+
+	if accessible.Load() {
+		_, _ = s.out.Write([]byte(line + "\n"))
+		s.lines = append(s.lines, line)
+
+		return
+	}
+
+	content := fmt.Sprintf("%s  %s", th.active(th.Bar), line)
+	_, _ = s.out.Write([]byte(content + "\n"))
 	s.lines = append(s.lines, line)
+	s.rows += countPhysicalLines(content, getColumns(s.out))
+}
+
+// repaint draws an open stream area again at the current terminal width.
+// With clear it first erases the area using the rows it took when drawn, as
+// after a resize; otherwise it draws below the cursor, as after the process
+// continues from a stop.
+func (s *Stream) repaint(clear bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.open || s.out == nil || accessible.Load() {
+		return
+	}
+
+	th := resolveTheme(s.opts.Theme)
+	cols := getColumns(s.out)
+
+	var b strings.Builder
+
+	if clear {
+		b.WriteString(strings.Repeat(CursorUp, s.rows) + "\r" + EraseDown)
+	}
+
+	title := fmt.Sprintf("%s  %s", th.Symbol(StateActive), s.title)
+	b.WriteString(title + "\n")
+	s.rows = countPhysicalLines(title, cols)
+
+	for _, line := range s.lines {
+		content := fmt.Sprintf("%s  %s", th.active(th.Bar), line)
+		b.WriteString(content + "\n")
+		s.rows += countPhysicalLines(content, cols)
+	}
+
+	_, _ = s.out.Write([]byte(b.String()))
 }
 
 // Pipe reads from r line-by-line and writes to the stream area.
@@ -98,8 +161,15 @@ func (s *Stream) Stop(finalMessage string, code int) {
 	}
 
 	s.open = false
+
+	if s.offOutput != nil {
+		s.offOutput()
+		s.offOutput = nil
+	}
+
 	start := s.start
 	showTimer := s.opts.ShowTimer
+	th := resolveTheme(s.opts.Theme)
 	out := s.out
 	s.mu.Unlock()
 
@@ -128,38 +198,42 @@ func (s *Stream) Stop(finalMessage string, code int) {
 	}
 	// Message itself remains white to align with design language
 
+	// Final status line with a diamond (aligned like header), white message; no bottom corner
+	statusSymbol := th.success(th.StepSubmit)
+	if code == 1 {
+		statusSymbol = th.danger(th.StepCancel)
+	} else if code > 1 {
+		statusSymbol = th.warning(th.StepError)
+	}
+
+	status := fmt.Sprintf("%s  %s\n", statusSymbol, msg)
+
+	// Accessible mode leaves printed lines as they are and adds the status.
+	if accessible.Load() {
+		writeLine(out, plainFrame(status))
+		return
+	}
+
 	// Visually deactivate: repaint previously printed content lines with gray bars.
-	// Move cursor up by the number of content lines we printed, then rewrite each line.
+	// Move cursor up by the rows the header and content took, which may be more
+	// than one each when lines wrapped, then rewrite each line.
 	s.mu.Lock()
-	lineCount := len(s.lines)
 	lines := append([]string(nil), s.lines...)
 	title := s.title
+	rows := s.rows
 	s.mu.Unlock()
 
 	// Move up to the header (one line above first content line)
-	for i := 0; i < lineCount+1; i++ {
-		_, _ = out.Write([]byte(CursorUp))
-	}
-	// Rewrite header: inactive diamond, title stays white
+	_, _ = out.Write([]byte(strings.Repeat(CursorUp, rows)))
 	_, _ = out.Write([]byte("\r"))
-	_, _ = out.Write([]byte(EraseLine))
-	_, _ = fmt.Fprintf(out, "%s  %s\n", green(StepSubmit), title)
+	_, _ = out.Write([]byte(EraseDown))
+	// Rewrite header: inactive diamond, title stays white
+	_, _ = fmt.Fprintf(out, "%s  %s\n", th.success(th.StepSubmit), title)
 
 	// Repaint content lines with gray bars and dimmed text
-	for i := range lineCount {
-		_, _ = out.Write([]byte("\r"))
-		_, _ = out.Write([]byte(EraseLine))
-		_, _ = fmt.Fprintf(out, "%s  %s\n", gray(Bar), dim(lines[i]))
+	for _, line := range lines {
+		_, _ = fmt.Fprintf(out, "%s  %s\n", th.muted(th.Bar), dim(line))
 	}
 
-	// Final status line with a diamond (aligned like header), white message; no bottom corner
-	statusSymbol := green(StepSubmit)
-	if code == 1 {
-		statusSymbol = red(StepCancel)
-	} else if code > 1 {
-		statusSymbol = yellow(StepError)
-	}
-
-	status := fmt.Sprintf("%s  %s\n", statusSymbol, msg)
 	_, _ = out.Write([]byte(status))
 }
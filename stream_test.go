@@ -83,3 +83,62 @@ func TestStream_PipeReader(t *testing.T) {
 	assert.Contains(t, joined, cyan(Bar)+"  line 2")
 	assert.Contains(t, joined, cyan(Bar)+"  line 3")
 }
+
+func TestStream_StopMovesUpOverWrappedLines(t *testing.T) {
+	out := NewMockWritable()
+	st := NewStream(StreamOptions{Output: out})
+
+	st.Start("Build")
+	st.WriteLine(strings.Repeat("x", 100)) // wraps at the default 80 columns
+	st.Stop("Done", 0)
+
+	joined := strings.Join(out.GetFrames(), "")
+	assert.Contains(t, joined, strings.Repeat(CursorUp, 3)+"\r"+EraseDown)
+}
+
+func TestStream_ResizeRepaints(t *testing.T) {
+	out := NewMockWritable()
+	st := NewStream(StreamOptions{Output: out})
+
+	st.Start("Build")
+	st.WriteLine("step 1")
+	st.WriteLine("step 2")
+
+	before := screenOf(out)
+
+	out.Emit("resize")
+
+	frames := out.GetFrames()
+	assert.True(t, strings.HasPrefix(frames[len(frames)-1], strings.Repeat(CursorUp, 3)+"\r"+EraseDown))
+	assert.Equal(t, before, screenOf(out))
+
+	st.Stop("Done", 0)
+
+	n := len(out.GetFrames())
+	out.Emit("resize")
+
+	assert.Len(t, out.GetFrames(), n, "expected no repaint after Stop")
+}
+
+func TestStream_RedrawsAfterContinue(t *testing.T) {
+	out := NewMockWritable()
+	st := NewStream(StreamOptions{Output: out})
+
+	st.Start("Build")
+	st.WriteLine("step 1")
+
+	n := len(out.GetFrames())
+	out.Emit("continue")
+
+	redraw := strings.Join(out.GetFrames()[n:], "")
+	assert.NotContains(t, redraw, CursorUp)
+	assert.Contains(t, redraw, "Build")
+	assert.Contains(t, redraw, "step 1")
+
+	// Stop repaints only the area drawn after the continue
+	st.Stop("Done", 0)
+
+	screen := screenOf(out)
+	assert.Equal(t, 2, strings.Count(screen, "step 1"))
+	assert.Contains(t, screen, cyan(Bar)+"  step 1", "expected the first area to be left alone")
+}
@@ -15,6 +15,7 @@ const (
 	BarStartRight = "┐"
 	BarEnd        = "└"
 	BarEndRight   = "┘"
+	ScrollThumb   = "┃"
 
 	// Corner symbols (rounded).
 	CornerTopLeft     = "╭"
@@ -60,20 +61,10 @@ func bold(s string) string          { return Bold + s + Reset }
 func inverse(s string) string       { return Inverse + s + Reset }
 func strikethrough(s string) string { return Strikethrough + s + Reset }
 
-// Symbol returns the appropriate symbol for a given state with color.
+// Symbol returns the appropriate symbol for a given state with color,
+// using the global theme.
 func Symbol(state ClackState) string {
-	switch state {
-	case StateInitial, StateActive:
-		return cyan(StepActive)
-	case StateCancel:
-		return red(StepCancel)
-	case StateError:
-		return yellow(StepError)
-	case StateSubmit:
-		return green(StepSubmit)
-	}
-
-	return StepActive
+	return resolveTheme(nil).Symbol(state)
 }
 
 // Table symbols.
@@ -17,13 +17,15 @@ func Table(headers []string, rows [][]string, opts TableOptions) {
 		return
 	}
 
+	out = withColorProfile(out)
+
 	if len(headers) == 0 {
 		return
 	}
 
 	// Set defaults
 	if opts.MaxWidth <= 0 {
-		opts.MaxWidth = 80
+		opts.MaxWidth = getColumns(out)
 	}
 
 	formatBorder := opts.FormatBorder
@@ -31,9 +33,11 @@ func Table(headers []string, rows [][]string, opts TableOptions) {
 		formatBorder = defaultBorderFormat
 	}
 
+	th := resolveTheme(opts.Theme)
+
 	linePrefix := ""
 	if opts.IncludePrefix {
-		linePrefix = gray(Bar) + " "
+		linePrefix = th.muted(th.Bar) + " "
 	}
 
 	// Calculate column widths
@@ -201,20 +205,21 @@ func normalizeRows(rows [][]string, numCols int) [][]string {
 
 // renderTableWithBorders renders a table with full borders.
 func renderTableWithBorders(out Writer, headers []string, rows [][]string, columnWidths []int, linePrefix string, formatBorder func(string) string, opts TableOptions) {
+	th := resolveTheme(opts.Theme)
 	numCols := len(headers)
 
 	// Top border
 	_, _ = fmt.Fprint(out, linePrefix)
 
-	_, _ = fmt.Fprint(out, formatBorder(TableTopLeft))
+	_, _ = fmt.Fprint(out, formatBorder(th.TableTopLeft))
 	for i, width := range columnWidths {
-		_, _ = fmt.Fprint(out, strings.Repeat(formatBorder(TableHorizontal), width))
+		_, _ = fmt.Fprint(out, strings.Repeat(formatBorder(th.TableHorizontal), width))
 		if i < numCols-1 {
-			_, _ = fmt.Fprint(out, formatBorder(TableTopTee))
+			_, _ = fmt.Fprint(out, formatBorder(th.TableTopTee))
 		}
 	}
 
-	_, _ = fmt.Fprint(out, formatBorder(TableTopRight))
+	_, _ = fmt.Fprint(out, formatBorder(th.TableTopRight))
 	_, _ = fmt.Fprint(out, "\n")
 
 	// Header row
@@ -223,15 +228,15 @@ func renderTableWithBorders(out Writer, headers []string, rows [][]string, colum
 	// Header separator
 	_, _ = fmt.Fprint(out, linePrefix)
 
-	_, _ = fmt.Fprint(out, formatBorder(TableLeftTee))
+	_, _ = fmt.Fprint(out, formatBorder(th.TableLeftTee))
 	for i, width := range columnWidths {
-		_, _ = fmt.Fprint(out, strings.Repeat(formatBorder(TableHorizontal), width))
+		_, _ = fmt.Fprint(out, strings.Repeat(formatBorder(th.TableHorizontal), width))
 		if i < numCols-1 {
-			_, _ = fmt.Fprint(out, formatBorder(TableCross))
+			_, _ = fmt.Fprint(out, formatBorder(th.TableCross))
 		}
 	}
 
-	_, _ = fmt.Fprint(out, formatBorder(TableRightTee))
+	_, _ = fmt.Fprint(out, formatBorder(th.TableRightTee))
 	_, _ = fmt.Fprint(out, "\n")
 
 	// Data rows
@@ -242,15 +247,15 @@ func renderTableWithBorders(out Writer, headers []string, rows [][]string, colum
 	// Bottom border
 	_, _ = fmt.Fprint(out, linePrefix)
 
-	_, _ = fmt.Fprint(out, formatBorder(TableBottomLeft))
+	_, _ = fmt.Fprint(out, formatBorder(th.TableBottomLeft))
 	for i, width := range columnWidths {
-		_, _ = fmt.Fprint(out, strings.Repeat(formatBorder(TableHorizontal), width))
+		_, _ = fmt.Fprint(out, strings.Repeat(formatBorder(th.TableHorizontal), width))
 		if i < numCols-1 {
-			_, _ = fmt.Fprint(out, formatBorder(TableBottomTee))
+			_, _ = fmt.Fprint(out, formatBorder(th.TableBottomTee))
 		}
 	}
 
-	_, _ = fmt.Fprint(out, formatBorder(TableBottomRight))
+	_, _ = fmt.Fprint(out, formatBorder(th.TableBottomRight))
 	_, _ = fmt.Fprint(out, "\n")
 }
 
@@ -267,6 +272,8 @@ func renderTableWithoutBorders(out Writer, headers []string, rows [][]string, co
 
 // renderTableRow renders a single table row.
 func renderTableRow(out Writer, row []string, columnWidths []int, linePrefix string, formatBorder func(string) string, opts TableOptions, isHeader bool) {
+	th := resolveTheme(opts.Theme)
+
 	_, _ = fmt.Fprint(out, linePrefix)
 
 	for i, cell := range row {
@@ -302,7 +309,7 @@ func renderTableRow(out Writer, row []string, columnWidths []int, linePrefix str
 
 		// Add borders if needed
 		if formatBorder != nil {
-			_, _ = fmt.Fprint(out, formatBorder(TableVertical))
+			_, _ = fmt.Fprint(out, formatBorder(th.TableVertical))
 		}
 
 		_, _ = fmt.Fprint(out, " ")
@@ -312,7 +319,7 @@ func renderTableRow(out Writer, row []string, columnWidths []int, linePrefix str
 
 	// Right border
 	if formatBorder != nil {
-		_, _ = fmt.Fprint(out, formatBorder(TableVertical))
+		_, _ = fmt.Fprint(out, formatBorder(th.TableVertical))
 	}
 
 	_, _ = fmt.Fprint(out, "\n")
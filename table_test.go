@@ -271,3 +271,17 @@ func TestTruncateTableText_IgnoresANSISequences(t *testing.T) {
 	assert.Contains(t, truncated, Cyan)
 	assert.Contains(t, truncated, Reset)
 }
+
+func TestTable_DefaultsToWriterWidth(t *testing.T) {
+	out := NewMockWritable()
+	out.SetSize(30, 0)
+
+	Table([]string{"Name", "Description"}, [][]string{{"tap", strings.Repeat("long text ", 10)}}, TableOptions{
+		Output:      out,
+		ShowBorders: true,
+	})
+
+	for _, line := range strings.Split(strings.Join(out.GetFrames(), ""), "\n") {
+		assert.LessOrEqual(t, visibleWidth(line), 30, "line %q exceeds the writer width", line)
+	}
+}
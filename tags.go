@@ -0,0 +1,245 @@
+package tap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Tags creates a styled prompt for entering multiple values as chips.
+// Enter or comma adds the typed value, Backspace on empty input removes the
+// last chip, and Enter on empty input submits the list.
+func Tags(ctx context.Context, opts TagsOptions) []string {
+	if opts.Input != nil && opts.Output != nil {
+		return tags(ctx, opts)
+	}
+
+	return runWithTerminal(func(in Reader, out Writer) []string {
+		if opts.Input == nil {
+			opts.Input = in
+		}
+
+		if opts.Output == nil {
+			opts.Output = out
+		}
+
+		return tags(ctx, opts)
+	})
+}
+
+type tagsState struct {
+	tags        []string
+	buf         []rune
+	cur         int
+	suggestions []string
+	selected    int
+}
+
+func tags(ctx context.Context, opts TagsOptions) []string {
+	th := resolveTheme(opts.Theme)
+
+	maxResults := opts.MaxResults
+	if maxResults <= 0 {
+		maxResults = 5
+	}
+
+	state := &tagsState{}
+	for _, t := range opts.InitialValues {
+		if t = strings.TrimSpace(t); t != "" && !slices.Contains(state.tags, t) {
+			state.tags = append(state.tags, t)
+		}
+	}
+
+	// suggest computes suggestions for the pending input, hiding values that
+	// were already added.
+	suggest := func() {
+		state.suggestions = nil
+		state.selected = 0
+
+		if opts.Suggest == nil || len(state.buf) == 0 {
+			return
+		}
+
+		for _, sg := range opts.Suggest(string(state.buf)) {
+			if slices.Contains(state.tags, sg) {
+				continue
+			}
+
+			state.suggestions = append(state.suggestions, sg)
+			if len(state.suggestions) == maxResults {
+				break
+			}
+		}
+	}
+
+	p := NewPromptWithTracking(PromptOptions{
+		Input:  opts.Input,
+		Output: opts.Output,
+		Render: func(p *Prompt) string {
+			s := p.StateSnapshot()
+
+			title := th.muted(th.Bar) + "\n" + th.Symbol(s) + "  " + opts.Message + "\n"
+
+			switch s {
+			case StateSubmit:
+				if len(state.tags) == 0 {
+					return title + th.muted(th.Bar)
+				}
+
+				return title + th.muted(th.Bar) + "  " + dim(strings.Join(state.tags, ", "))
+
+			case StateCancel:
+				if len(state.tags) == 0 {
+					return title + th.muted(th.Bar)
+				}
+
+				return title + th.muted(th.Bar) + "  " + strikethrough(dim(strings.Join(state.tags, ", "))) + "\n" + th.muted(th.Bar)
+
+			default:
+				barColor := th.active
+				if s == StateError {
+					barColor = th.warning
+				}
+
+				var input string
+				if len(state.buf) == 0 && len(state.tags) == 0 && opts.Placeholder != "" {
+					input = renderTextareaPlaceholder(opts.Placeholder)
+				} else {
+					input = renderTextWithCursor(string(state.buf), state.cur, StateActive)
+				}
+
+				chips := make([]string, 0, len(state.tags)+1)
+				for _, t := range state.tags {
+					chips = append(chips, th.active("["+t+"]"))
+				}
+
+				chips = append(chips, input)
+
+				result := title + barColor(th.Bar) + "  " + strings.Join(chips, " ") + "\n"
+
+				query := string(state.buf)
+				for i, sg := range state.suggestions {
+					matches := fuzzyPositions(query, sg)
+					if i == state.selected {
+						result += fmt.Sprintf("%s  %s %s\n", barColor(th.Bar), th.success(th.RadioActive), highlightMatches(th, sg, matches, nil))
+					} else {
+						result += fmt.Sprintf("%s  %s %s\n", barColor(th.Bar), dim(th.RadioInactive), highlightMatches(th, sg, matches, dim))
+					}
+				}
+
+				if s == StateError {
+					return result + th.warning(th.BarEnd) + "  " + th.warning(p.ErrorSnapshot())
+				}
+
+				return result + barColor(th.BarEnd)
+			}
+		},
+	}, false)
+
+	setError := func(msg string) {
+		p.cur.Error = msg
+		p.cur.State = StateError
+	}
+
+	// add validates and appends the pending input as a chip.
+	add := func() {
+		tag := strings.TrimSpace(string(state.buf))
+		if tag == "" {
+			return
+		}
+
+		if slices.Contains(state.tags, tag) {
+			setError(fmt.Sprintf(resolveLocale(opts.Locale).TagExists, tag))
+			return
+		}
+
+		if opts.Validate != nil {
+			if err := opts.Validate(tag); err != nil {
+				errMsg := err.Error()
+				e := &ValidationError{}
+				if errors.As(err, &e) {
+					errMsg = e.Message
+				}
+
+				setError(errMsg)
+
+				return
+			}
+		}
+
+		state.tags = append(state.tags, tag)
+		state.buf = nil
+		state.cur = 0
+	}
+
+	p.On("key", func(_ string, key Key) {
+		switch {
+		case key.Name == "return" && len(state.buf) > 0:
+			add()
+
+			p.cur.consumed = true
+		case key.Name == "return":
+			// Empty input: let the prompt submit the collected tags
+		case key.Name == ",":
+			add()
+		case key.Name == "left":
+			if state.cur > 0 {
+				state.cur--
+			}
+		case key.Name == "right":
+			if state.cur < len(state.buf) {
+				state.cur++
+			}
+		case key.Name == "up":
+			if len(state.suggestions) > 0 {
+				state.selected = (state.selected - 1 + len(state.suggestions)) % len(state.suggestions)
+			}
+
+			return
+		case key.Name == "down":
+			if len(state.suggestions) > 0 {
+				state.selected = (state.selected + 1) % len(state.suggestions)
+			}
+
+			return
+		case key.Name == "tab":
+			if len(state.suggestions) > 0 {
+				state.buf = []rune(state.suggestions[state.selected])
+				state.cur = len(state.buf)
+			}
+		case key.Name == "backspace":
+			switch {
+			case state.cur > 0:
+				state.buf = slices.Delete(state.buf, state.cur-1, state.cur)
+				state.cur--
+			case len(state.buf) == 0 && len(state.tags) > 0:
+				state.tags = state.tags[:len(state.tags)-1]
+			}
+		case key.Name == "delete":
+			if state.cur < len(state.buf) {
+				state.buf = slices.Delete(state.buf, state.cur, state.cur+1)
+			}
+		default:
+			if !key.Ctrl && key.Rune >= 32 && key.Rune <= 126 {
+				state.buf = slices.Insert(state.buf, state.cur, key.Rune)
+				state.cur++
+			}
+		}
+
+		suggest()
+		p.SetImmediateValue(slices.Clone(state.tags))
+	})
+
+	if len(state.tags) > 0 {
+		p.SetImmediateValue(slices.Clone(state.tags))
+	}
+
+	v := p.Prompt(ctx)
+	if t, ok := v.([]string); ok {
+		return t
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package tap
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func typeString(in *MockReadable, s string) {
+	for _, r := range s {
+		if r == ' ' {
+			in.EmitKeypress(" ", Key{Name: "space", Rune: ' '})
+			continue
+		}
+
+		in.EmitKeypress(string(r), Key{Name: string(r), Rune: r})
+	}
+}
+
+func TestTags_EnterAndCommaAddChips(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	resultCh := make(chan []string, 1)
+
+	go func() {
+		resultCh <- Tags(context.Background(), TagsOptions{
+			Message: "Labels:",
+			Input:   in,
+			Output:  out,
+		})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	typeString(in, "bug")
+	in.EmitKeypress("", Key{Name: "return"})
+	typeString(in, "UI,")
+	typeString(in, "needs triage")
+	in.EmitKeypress("", Key{Name: "return"})
+	time.Sleep(5 * time.Millisecond)
+
+	frames := out.GetFrames()
+	if last := frames[len(frames)-1]; !strings.Contains(last, "[bug]") || !strings.Contains(last, "[UI]") {
+		t.Errorf("expected chips in frame, got %q", last)
+	}
+
+	in.EmitKeypress("", Key{Name: "return"})
+
+	got := <-resultCh
+	if !slices.Equal(got, []string{"bug", "UI", "needs triage"}) {
+		t.Fatalf("unexpected tags %q", got)
+	}
+}
+
+func TestTags_BackspaceOnEmptyRemovesLastChip(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	resultCh := make(chan []string, 1)
+
+	go func() {
+		resultCh <- Tags(context.Background(), TagsOptions{
+			Message:       "Labels:",
+			InitialValues: []string{"a", "b"},
+			Input:         in,
+			Output:        out,
+		})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	typeString(in, "c")
+	in.EmitKeypress("", Key{Name: "backspace"}) // deletes the typed "c"
+	in.EmitKeypress("", Key{Name: "backspace"}) // removes chip "b"
+	in.EmitKeypress("", Key{Name: "return"})
+
+	got := <-resultCh
+	if !slices.Equal(got, []string{"a"}) {
+		t.Fatalf("expected [a], got %q", got)
+	}
+}
+
+func TestTags_RejectsDuplicatesAndInvalidItems(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	resultCh := make(chan []string, 1)
+
+	go func() {
+		resultCh <- Tags(context.Background(), TagsOptions{
+			Message: "Recipients:",
+			Validate: func(s string) error {
+				if !strings.Contains(s, "@") {
+					return errors.New("not an email")
+				}
+
+				return nil
+			},
+			Input:  in,
+			Output: out,
+		})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	typeString(in, "bob")
+	in.EmitKeypress("", Key{Name: "return"})
+	time.Sleep(5 * time.Millisecond)
+
+	frames := out.GetFrames()
+	if last := frames[len(frames)-1]; !strings.Contains(last, "not an email") || !strings.Contains(last, "▲") {
+		t.Fatalf("expected validation error, got %q", last)
+	}
+
+	typeString(in, "@x.io")
+	in.EmitKeypress("", Key{Name: "return"})
+	typeString(in, "bob@x.io")
+	in.EmitKeypress("", Key{Name: "return"})
+	time.Sleep(5 * time.Millisecond)
+
+	frames = out.GetFrames()
+	if last := frames[len(frames)-1]; !strings.Contains(last, "already added") {
+		t.Fatalf("expected duplicate error, got %q", last)
+	}
+
+	for range len("bob@x.io") {
+		in.EmitKeypress("", Key{Name: "backspace"})
+	}
+
+	in.EmitKeypress("", Key{Name: "return"})
+
+	got := <-resultCh
+	if !slices.Equal(got, []string{"bob@x.io"}) {
+		t.Fatalf("expected [bob@x.io], got %q", got)
+	}
+}
+
+func TestTags_SuggestCompletesChip(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	resultCh := make(chan []string, 1)
+
+	go func() {
+		resultCh <- Tags(context.Background(), TagsOptions{
+			Message: "Labels:",
+			Suggest: suggestFn([]string{"backend", "frontend", "bug"}),
+			Input:   in,
+			Output:  out,
+		})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	typeString(in, "end")
+	in.EmitKeypress("", Key{Name: "down"})
+	in.EmitKeypress("\t", Key{Name: "tab"})
+	in.EmitKeypress("", Key{Name: "return"})
+	in.EmitKeypress("", Key{Name: "return"})
+
+	got := <-resultCh
+	if !slices.Equal(got, []string{"frontend"}) {
+		t.Fatalf("expected [frontend], got %q", got)
+	}
+}
+
+func TestTags_CancelReturnsNil(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	resultCh := make(chan []string, 1)
+
+	go func() {
+		resultCh <- Tags(context.Background(), TagsOptions{
+			Message:       "Labels:",
+			InitialValues: []string{"a"},
+			Input:         in,
+			Output:        out,
+		})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	in.EmitKeypress("", Key{Name: "escape"})
+
+	if got := <-resultCh; got != nil {
+		t.Fatalf("expected nil on cancel, got %q", got)
+	}
+}
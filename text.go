@@ -2,6 +2,7 @@ package tap
 
 import (
 	"context"
+	"fmt"
 	"strings"
 )
 
@@ -26,6 +27,8 @@ func Text(ctx context.Context, opts TextOptions) string {
 
 // text implements the core text prompt logic.
 func text(ctx context.Context, opts TextOptions) string {
+	th := resolveTheme(opts.Theme)
+
 	var validate func(any) error
 	if opts.Validate != nil {
 		validate = func(v any) error {
@@ -40,13 +43,16 @@ func text(ctx context.Context, opts TextOptions) string {
 		Validate:         validate,
 		InitialUserInput: opts.InitialValue,
 		InitialValue:     opts.DefaultValue,
+		MaxLength:        opts.MaxLength,
+		PasteNewlines:    opts.PasteNewlines,
+		Locale:           opts.Locale,
 		Render: func(p *Prompt) string {
 			s := p.StateSnapshot()
 			userInput := p.UserInputSnapshot()
 			cursor := p.CursorSnapshot()
 
 			// Create title with symbol and message
-			title := gray(Bar) + "\n" + Symbol(s) + "  " + opts.Message + "\n"
+			title := th.muted(th.Bar) + "\n" + th.Symbol(s) + "  " + opts.Message + "\n"
 
 			// Handle placeholder and cursor
 			var displayInput string
@@ -66,7 +72,7 @@ func text(ctx context.Context, opts TextOptions) string {
 			switch s {
 			case StateError:
 				errMsg := p.ErrorSnapshot()
-				return title + yellow(Bar) + "  " + displayInput + "\n" + yellow(BarEnd) + "  " + yellow(errMsg)
+				return title + th.warning(th.Bar) + "  " + displayInput + "\n" + th.warning(th.BarEnd) + "  " + th.warning(errMsg)
 
 			case StateSubmit:
 				value := ""
@@ -79,7 +85,7 @@ func text(ctx context.Context, opts TextOptions) string {
 					valueText = "  " + dim(value)
 				}
 
-				return title + gray(Bar) + valueText
+				return title + th.muted(th.Bar) + valueText
 
 			case StateCancel:
 				value := ""
@@ -92,15 +98,25 @@ func text(ctx context.Context, opts TextOptions) string {
 					valueText = "  " + strikethrough(dim(value))
 				}
 
-				result := title + gray(Bar) + valueText
+				result := title + th.muted(th.Bar) + valueText
 				if strings.TrimSpace(value) != "" {
-					result += "\n" + gray(Bar)
+					result += "\n" + th.muted(th.Bar)
 				}
 
 				return result
 
 			default:
-				return title + cyan(Bar) + "  " + displayInput + "\n" + cyan(BarEnd)
+				counter := ""
+				if opts.MaxLength > 0 {
+					n := len([]rune(userInput))
+					counter = "  " + renderLimitCounter(th, fmt.Sprintf("%d/%d", n, opts.MaxLength), n, opts.MaxLength)
+				}
+
+				if notice := p.noticeSnapshot(); notice != "" {
+					counter += "  " + th.warning(notice)
+				}
+
+				return title + th.active(th.Bar) + "  " + displayInput + "\n" + th.active(th.BarEnd) + counter
 			}
 		},
 	})
@@ -109,6 +125,8 @@ func text(ctx context.Context, opts TextOptions) string {
 		p.SetImmediateValue(input)
 	})
 
+	enableBracketedPaste(p, opts.Output)
+
 	v := p.Prompt(ctx)
 	if s, ok := v.(string); ok {
 		return s
@@ -134,3 +152,13 @@ func renderTextWithCursor(text string, cursor int, state ClackState) string {
 
 	return before + inverse(char) + after
 }
+
+// renderLimitCounter renders a counter such as "n/limit", highlighted once
+// the value n is within 10% of the limit.
+func renderLimitCounter(th *Theme, counter string, n, limit int) string {
+	if n*10 >= limit*9 {
+		return th.warning(counter)
+	}
+
+	return dim(counter)
+}
@@ -2,6 +2,7 @@ package tap
 
 import (
 	"context"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -262,3 +263,216 @@ func TestStyledText_HandlesPastedText(t *testing.T) {
 		t.Fatal("Test timed out - app likely hung during paste simulation")
 	}
 }
+
+func TestStyledText_MaxLengthBlocksInputAndShowsCounter(t *testing.T) {
+	mock := NewMockReadable()
+	out := NewMockWritable()
+
+	done := make(chan string, 1)
+
+	go func() {
+		done <- Text(context.Background(), TextOptions{
+			Message:   "Subject:",
+			MaxLength: 10,
+			Input:     mock,
+			Output:    out,
+		})
+	}()
+
+	time.Sleep(time.Millisecond)
+
+	for _, ch := range "abcdefgh" {
+		mock.EmitKeypress(string(ch), Key{Name: string(ch)})
+	}
+
+	time.Sleep(10 * time.Millisecond)
+
+	frames := out.GetFrames()
+	if last := frames[len(frames)-1]; !strings.Contains(last, dim("8/10")) {
+		t.Errorf("expected dim counter, got %q", last)
+	}
+
+	for _, ch := range "ijkl" {
+		mock.EmitKeypress(string(ch), Key{Name: string(ch)})
+	}
+
+	time.Sleep(10 * time.Millisecond)
+
+	frames = out.GetFrames()
+	if last := frames[len(frames)-1]; !strings.Contains(last, yellow("10/10")) {
+		t.Errorf("expected highlighted counter at the limit, got %q", last)
+	}
+
+	// Editing below the limit is still possible
+	mock.EmitKeypress("", Key{Name: "left"})
+	mock.EmitKeypress("", Key{Name: "backspace"})
+	mock.EmitKeypress("x", Key{Name: "x"})
+	mock.EmitKeypress("y", Key{Name: "y"})
+	mock.EmitKeypress("", Key{Name: "return"})
+
+	if got := <-done; got != "abcdefghxj" {
+		t.Fatalf("expected input capped at 10 runes, got %q", got)
+	}
+}
+
+func TestLimitInput(t *testing.T) {
+	tests := []struct {
+		name          string
+		old, new      string
+		cursor, limit int
+		want          string
+		wantCursor    int
+	}{
+		{"within limit", "ab", "abc", 3, 5, "abc", 3},
+		{"insert at end", "abcd", "abcdxyz", 7, 5, "abcdx", 5},
+		{"insert in middle", "abcd", "abxyzcd", 5, 5, "abxcd", 3},
+		{"over-long initial can shrink", "abcdef", "abcde", 5, 3, "abcde", 5},
+		{"over-long initial cannot grow", "abcdef", "abcdefg", 7, 3, "abcdef", 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, cursor := limitInput(tt.old, tt.new, tt.cursor, tt.limit)
+			if got != tt.want || cursor != tt.wantCursor {
+				t.Errorf("got (%q, %d), want (%q, %d)", got, cursor, tt.want, tt.wantCursor)
+			}
+		})
+	}
+}
+
+func TestStyledText_BracketedPaste(t *testing.T) {
+	mock := NewMockReadable()
+	out := NewMockWritable()
+
+	done := make(chan string, 1)
+
+	go func() {
+		done <- Text(context.Background(), TextOptions{
+			Message:   "Token:",
+			MaxLength: 12,
+			Input:     mock,
+			Output:    out,
+		})
+	}()
+
+	time.Sleep(time.Millisecond)
+	mock.EmitKeypress("[", Key{Name: "["})
+	mock.EmitKeypress("]", Key{Name: "]"})
+	mock.EmitKeypress("", Key{Name: "left"})
+	mock.EmitPaste("abc\ndef-ghijkl\n")
+	mock.EmitKeypress("", Key{Name: "return"})
+
+	if got := <-done; got != "[abc def-gh]" {
+		t.Fatalf("expected paste inserted at cursor and truncated, got %q", got)
+	}
+
+	if out.Buffer[0] != bracketedPasteEnable || !slices.Contains(out.Buffer, bracketedPasteDisable) {
+		t.Errorf("expected bracketed paste to be enabled then disabled, got %q", out.Buffer)
+	}
+}
+
+func TestStyledText_TruncatedPasteShowsNotice(t *testing.T) {
+	mock := NewMockReadable()
+	out := NewMockWritable()
+
+	done := make(chan string, 1)
+
+	go func() {
+		done <- Text(context.Background(), TextOptions{
+			Message:   "Code:",
+			MaxLength: 4,
+			Input:     mock,
+			Output:    out,
+		})
+	}()
+
+	notice := EnglishLocale().PasteTruncated
+
+	time.Sleep(time.Millisecond)
+	mock.EmitPaste("abcdef")
+	time.Sleep(5 * time.Millisecond)
+
+	if screen := screenOf(out); !strings.Contains(screen, notice) {
+		t.Errorf("expected the truncation notice, got:\n%s", screen)
+	}
+
+	mock.EmitKeypress("", Key{Name: "backspace"})
+	time.Sleep(5 * time.Millisecond)
+
+	if screen := screenOf(out); strings.Contains(screen, notice) {
+		t.Errorf("expected the notice to clear on the next key, got:\n%s", screen)
+	}
+
+	mock.EmitKeypress("", Key{Name: "return"})
+
+	if got := <-done; got != "abc" {
+		t.Errorf("expected %q, got %q", "abc", got)
+	}
+}
+
+func TestStyledText_PasteRejectsNewlines(t *testing.T) {
+	mock := NewMockReadable()
+	out := NewMockWritable()
+
+	done := make(chan string, 1)
+
+	go func() {
+		done <- Text(context.Background(), TextOptions{
+			Message:       "Name:",
+			PasteNewlines: NewlinesReject,
+			Input:         mock,
+			Output:        out,
+		})
+	}()
+
+	time.Sleep(time.Millisecond)
+	mock.EmitPaste("one\ntwo")
+	time.Sleep(10 * time.Millisecond)
+
+	frames := out.GetFrames()
+	if last := frames[len(frames)-1]; !strings.Contains(last, "Pasted text contains line breaks") {
+		t.Errorf("expected rejection error, got %q", last)
+	}
+
+	mock.EmitPaste("one")
+	mock.EmitKeypress("", Key{Name: "return"})
+
+	if got := <-done; got != "one" {
+		t.Fatalf("expected only the accepted paste, got %q", got)
+	}
+}
+
+func TestText_TypingRedrawsInPlace(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	done := make(chan string, 1)
+
+	go func() {
+		done <- Text(context.Background(), TextOptions{Message: "Name:", Input: in, Output: out})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+
+	for _, r := range "abc" {
+		in.EmitKeypress(string(r), Key{Name: string(r), Rune: r})
+		time.Sleep(time.Millisecond)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	screen := screenOf(out)
+	if n := strings.Count(screen, "Name:"); n != 1 {
+		t.Fatalf("expected one frame on screen, got %d:\n%s", n, screen)
+	}
+
+	if !strings.Contains(screen, "abc") {
+		t.Errorf("expected the typed input on screen, got:\n%s", screen)
+	}
+
+	in.EmitKeypress("", Key{Name: "return"})
+
+	if got := <-done; got != "abc" {
+		t.Errorf("expected %q, got %q", "abc", got)
+	}
+}
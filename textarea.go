@@ -5,13 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
-)
+	"unicode/utf8"
 
-// Bracketed paste mode escape sequences.
-const (
-	bracketedPasteEnable  = "\x1b[?2004h"
-	bracketedPasteDisable = "\x1b[?2004l"
+	"github.com/mattn/go-runewidth"
 )
 
 // PUA rune helpers for paste placeholder encoding.
@@ -55,27 +53,88 @@ func Textarea(ctx context.Context, opts TextareaOptions) string {
 }
 
 func textarea(ctx context.Context, opts TextareaOptions) string {
+	th := resolveTheme(opts.Theme)
+	loc := resolveLocale(opts.Locale)
+
 	// Local buffer state (track=false, same pattern as Autocomplete)
 	var (
 		buf          []rune
 		cur          int
+		top          int // first visible row of the viewport
 		pasteCounter int
 		pasteBuffers = make(map[int]string)
 	)
 
-	// Enable bracketed paste mode
-	if opts.Output != nil {
-		_, _ = opts.Output.Write([]byte(bracketedPasteEnable))
+	indent := opts.IndentWidth
+	if indent <= 0 {
+		indent = 2
+	}
+
+	// gutterWidth returns the columns taken by line numbers, including the separating space.
+	gutterWidth := func() int {
+		if !opts.LineNumbers {
+			return 0
+		}
+
+		return len(strconv.Itoa(countBufferLines(buf))) + 1
+	}
+
+	// notice is a transient message shown next to the counters until the next key
+	notice := ""
+
+	// room returns how many more runes and newlines fit alongside used; -1 means unlimited.
+	room := func(used string) (runes, newlines int) {
+		runes, newlines = -1, -1
+
+		if opts.MaxLength > 0 {
+			runes = max(opts.MaxLength-len([]rune(used)), 0)
+		}
+
+		if opts.MaxLines > 0 {
+			newlines = max(opts.MaxLines-1-strings.Count(used, "\n"), 0)
+		}
+
+		return runes, newlines
+	}
+
+	// footer renders the live counters and any notice after the bar end.
+	footer := func() string {
+		val := resolve(buf, pasteBuffers)
+
+		var b strings.Builder
+
+		if opts.MaxLength > 0 {
+			n := len([]rune(val))
+			b.WriteString("  " + renderLimitCounter(th, fmt.Sprintf("%d/%d", n, opts.MaxLength), n, opts.MaxLength))
+		}
+
+		if opts.MaxLines > 0 {
+			lines := strings.Count(val, "\n") + 1
+			b.WriteString("  " + renderLimitCounter(th, loc.Plural(loc.LinesCounter, opts.MaxLines, lines, opts.MaxLines), lines, opts.MaxLines))
+		}
+
+		if notice != "" {
+			b.WriteString("  " + th.warning(notice))
+		}
+
+		return b.String()
+	}
+
+	var p *Prompt
+
+	layout := func() []textareaRow {
+		return layoutTextarea(buf, max(textareaWidth(p.output)-gutterWidth(), 1), loc.PastePlaceholder)
 	}
 
-	p := NewPromptWithTracking(PromptOptions{
+	p = NewPromptWithTracking(PromptOptions{
 		Input:        opts.Input,
 		Output:       opts.Output,
 		InitialValue: opts.DefaultValue,
+		Mouse:        opts.Mouse,
 		Render: func(p *Prompt) string {
 			s := p.StateSnapshot()
 
-			title := gray(Bar) + "\n" + Symbol(s) + "  " + opts.Message + "\n"
+			title := th.muted(th.Bar) + "\n" + th.Symbol(s) + "  " + opts.Message + "\n"
 
 			switch s {
 			case StateSubmit:
@@ -85,14 +144,14 @@ func textarea(ctx context.Context, opts TextareaOptions) string {
 				}
 
 				if value == "" {
-					return title + gray(Bar)
+					return title + th.muted(th.Bar)
 				}
 
 				lines := strings.Split(value, "\n")
 				var parts []string
 
 				for _, line := range lines {
-					parts = append(parts, gray(Bar)+"  "+dim(line))
+					parts = append(parts, th.muted(th.Bar)+"  "+dim(line))
 				}
 
 				return title + strings.Join(parts, "\n")
@@ -104,53 +163,74 @@ func textarea(ctx context.Context, opts TextareaOptions) string {
 				}
 
 				if value == "" {
-					return title + gray(Bar)
+					return title + th.muted(th.Bar)
 				}
 
 				lines := strings.Split(value, "\n")
 				var parts []string
 
 				for _, line := range lines {
-					parts = append(parts, gray(Bar)+"  "+strikethrough(dim(line)))
+					parts = append(parts, th.muted(th.Bar)+"  "+strikethrough(dim(line)))
 				}
 
 				return title + strings.Join(parts, "\n")
 
 			default:
 				// Active/Initial/Error state
-				barColor := cyan
+				barColor := th.active
 
 				if s == StateError {
-					barColor = yellow
+					barColor = th.warning
 				}
 
 				if len(buf) == 0 && opts.Placeholder != "" {
 					placeholder := renderTextareaPlaceholder(opts.Placeholder)
-					result := title + barColor(Bar) + "  " + placeholder + "\n" + barColor(BarEnd)
+					result := title + barColor(th.Bar) + "  " + placeholder + "\n" + barColor(th.BarEnd) + footer()
 
 					if s == StateError {
 						errMsg := p.ErrorSnapshot()
-						result = title + barColor(Bar) + "  " + placeholder + "\n" + barColor(BarEnd) + "  " + yellow(errMsg)
+						result = title + barColor(th.Bar) + "  " + placeholder + "\n" + barColor(th.BarEnd) + "  " + th.warning(errMsg)
 					}
 
 					return result
 				}
 
-				// Render buffer with PUA runes replaced by dim placeholders
-				content := renderBufWithPlaceholders(buf)
-				lines := strings.Split(content, "\n")
+				// Lay the buffer out in visual rows and show the window around the cursor
+				rows := layout()
+				height := textareaHeight(p.output, opts.MaxHeight, len(rows))
+				top = scrollToRow(top, cursorRow(rows, cur), height, len(rows))
+				thumbStart, thumbLen := scrollThumb(top, height, len(rows))
+				digits := gutterWidth() - 1
+				lineNo := countBufferLines(buf[:rows[top].start])
+
 				var parts []string
 
-				for i, line := range lines {
-					display := renderTextareaLine(line, buf, cur, i, s)
-					parts = append(parts, barColor(Bar)+"  "+display)
+				for i := top; i < top+height; i++ {
+					bar := barColor(th.Bar)
+					if offset := i - top; len(rows) > height && offset >= thumbStart && offset < thumbStart+thumbLen {
+						bar = barColor(th.ScrollThumb)
+					}
+
+					gutter := ""
+					if opts.LineNumbers {
+						gutter = strings.Repeat(" ", digits+1)
+						if i == 0 || rows[i-1].last {
+							if i > top {
+								lineNo++
+							}
+
+							gutter = dim(fmt.Sprintf("%*d", digits, lineNo)) + " "
+						}
+					}
+
+					parts = append(parts, bar+"  "+gutter+renderTextareaLine(buf, rows[i], cur, s, opts.Highlight, loc.PastePlaceholder))
 				}
 
-				result := title + strings.Join(parts, "\n") + "\n" + barColor(BarEnd)
+				result := title + strings.Join(parts, "\n") + "\n" + barColor(th.BarEnd) + footer()
 
 				if s == StateError {
 					errMsg := p.ErrorSnapshot()
-					result = title + strings.Join(parts, "\n") + "\n" + barColor(BarEnd) + "  " + yellow(errMsg)
+					result = title + strings.Join(parts, "\n") + "\n" + barColor(th.BarEnd) + "  " + th.warning(errMsg)
 				}
 
 				return result
@@ -158,12 +238,7 @@ func textarea(ctx context.Context, opts TextareaOptions) string {
 		},
 	}, false)
 
-	// Disable bracketed paste mode on finalize
-	p.On("finalize", func() {
-		if opts.Output != nil {
-			_, _ = opts.Output.Write([]byte(bracketedPasteDisable))
-		}
-	})
+	enableBracketedPaste(p, opts.Output)
 
 	// Initialize from InitialValue if provided
 	if opts.InitialValue != "" {
@@ -174,17 +249,92 @@ func textarea(ctx context.Context, opts TextareaOptions) string {
 
 	// Key handling
 	p.On("key", func(_ string, key Key) {
+		notice = ""
+		runesLeft, newlinesLeft := room(resolve(buf, pasteBuffers))
+
 		switch {
 		case key.Name == "paste":
+			content, cut := truncateText(key.Content, runesLeft, newlinesLeft)
+			if cut {
+				notice = loc.PasteTruncated
+			}
+
+			if content == "" {
+				break
+			}
+
 			pasteCounter++
-			pasteBuffers[pasteCounter] = key.Content
+			pasteBuffers[pasteCounter] = content
 			buf = slices.Insert(buf, cur, idToPUA(pasteCounter))
 			cur++
 
+		case key.Ctrl && key.Name == "e":
+			// Ctrl+E: edit the resolved buffer in $VISUAL/$EDITOR
+			edited, err := openInEditor(opts.Editor, resolve(buf, pasteBuffers), opts.Input, opts.Output)
+			if err != nil {
+				p.cur.Error = err.Error()
+				p.cur.State = StateError
+				p.cur.PrevFrame = "" // the editor may have drawn over the screen
+
+				return
+			}
+
+			maxRunes, maxNewlines := room("")
+
+			edited, cut := truncateText(edited, maxRunes, maxNewlines)
+			if cut {
+				notice = loc.ContentTruncated
+			}
+
+			buf = []rune(edited)
+			cur = len(buf)
+			pasteCounter = 0
+			pasteBuffers = make(map[int]string)
+
+			// The editor drew over the screen; repaint the whole frame
+			p.cur.PrevFrame = ""
+
 		case key.Name == "return" && key.Shift:
-			// Shift+Enter: insert newline
-			buf = slices.Insert(buf, cur, '\n')
-			cur++
+			// Shift+Enter: insert newline, carrying over the current line's indentation
+			if runesLeft == 0 || newlinesLeft == 0 {
+				break
+			}
+
+			start := lineStartIndex(buf, cur)
+			lead := start
+
+			for lead < cur && (buf[lead] == ' ' || buf[lead] == '\t') {
+				lead++
+			}
+
+			line := append([]rune{'\n'}, buf[start:lead]...)
+			if runesLeft > 0 {
+				line = line[:min(len(line), runesLeft)]
+			}
+
+			buf = slices.Insert(buf, cur, line...)
+			cur += len(line)
+
+		case key.Name == "tab" && key.Shift:
+			// Shift+Tab: outdent the current line
+			start := lineStartIndex(buf, cur)
+			n := 0
+
+			for n < indent && start+n < len(buf) && buf[start+n] == ' ' {
+				n++
+			}
+
+			buf = slices.Delete(buf, start, start+n)
+			cur = max(cur-n, start)
+
+		case key.Name == "tab":
+			// Tab: indent the current line
+			if runesLeft >= 0 && runesLeft < indent {
+				break
+			}
+
+			buf = slices.Insert(buf, lineStartIndex(buf, cur), slices.Repeat([]rune{' '}, indent)...)
+			cur += indent
 
 		case key.Name == "return":
 			val := resolve(buf, pasteBuffers)
@@ -205,7 +355,6 @@ func textarea(ctx context.Context, opts TextareaOptions) string {
 					p.cur.Value = val
 					p.cur.Error = errMsg
 					p.cur.State = StateError
-					p.cur.PrevFrame = "" // Force re-render
 					p.SetImmediateValue(val)
 					return
 				}
@@ -216,6 +365,20 @@ func textarea(ctx context.Context, opts TextareaOptions) string {
 
 			return
 
+		case key.Name == "click":
+			// Place the cursor at the clicked position of a visible row
+			rows := layout()
+			height := textareaHeight(p.output, opts.MaxHeight, len(rows))
+
+			i, ok := listItemAt(p, key.Y, titleLines(opts.Message), top, height)
+			if !ok || i >= len(rows) {
+				break
+			}
+
+			// Content starts after the bar, two spaces and the gutter
+			col := key.X - 1 - 3 - gutterWidth()
+			cur = rowIndexAt(buf, rows[i], max(col, 0), loc.PastePlaceholder)
+
 		case key.Name == "left":
 			if cur > 0 {
 				cur--
@@ -235,17 +398,10 @@ func textarea(ctx context.Context, opts TextareaOptions) string {
 			}
 
 		case key.Name == "up":
-			line, col := cursorToLineCol(buf, cur)
-			if line > 0 {
-				cur = lineColToCursor(buf, line-1, col)
-			}
+			cur = moveVisualRow(buf, layout(), cur, -1, loc.PastePlaceholder)
 
 		case key.Name == "down":
-			line, col := cursorToLineCol(buf, cur)
-			lineCount := countBufferLines(buf)
-			if line < lineCount-1 {
-				cur = lineColToCursor(buf, line+1, col)
-			}
+			cur = moveVisualRow(buf, layout(), cur, 1, loc.PastePlaceholder)
 
 		case key.Name == "home":
 			line, _ := cursorToLineCol(buf, cur)
@@ -276,7 +432,7 @@ func textarea(ctx context.Context, opts TextareaOptions) string {
 			}
 
 		default:
-			if key.Rune >= 32 && key.Rune <= 126 {
+			if key.Rune >= 32 && key.Rune <= 126 && runesLeft != 0 {
 				buf = slices.Insert(buf, cur, key.Rune)
 				cur++
 			}
@@ -293,21 +449,6 @@ func textarea(ctx context.Context, opts TextareaOptions) string {
 	return ""
 }
 
-// renderBufWithPlaceholders converts a buffer to a display string,
-// replacing PUA runes with dim "[Text N]" placeholders.
-func renderBufWithPlaceholders(buf []rune) string {
-	var b strings.Builder
-	for _, r := range buf {
-		if isPUA(r) {
-			b.WriteString(dim(fmt.Sprintf("[Text %d]", puaToID(r))))
-		} else {
-			b.WriteRune(r)
-		}
-	}
-
-	return b.String()
-}
-
 // renderTextareaPlaceholder renders placeholder text with inverse first char + dim rest.
 func renderTextareaPlaceholder(placeholder string) string {
 	runes := []rune(placeholder)
@@ -318,92 +459,329 @@ func renderTextareaPlaceholder(placeholder string) string {
 	return inverse(string(runes[0])) + dim(string(runes[1:]))
 }
 
-// renderTextareaLine renders a single line of textarea content with cursor display.
-// The line parameter contains rendered text (with ANSI for PUA placeholders).
-// Cursor positioning uses the raw buf to map cursor index to display position.
-func renderTextareaLine(line string, buf []rune, cursor, lineIdx int, state ClackState) string {
-	if state != StateActive && state != StateInitial {
-		return line
+// textareaRow is one visual row of the buffer: the runes in [start, end).
+// last reports whether the row ends its logical line (at a newline or the
+// end of the buffer) rather than at a soft wrap.
+type textareaRow struct {
+	start, end int
+	last       bool
+}
+
+// placeholderText returns the label shown in place of a paste rune, built
+// from the locale's PastePlaceholder format.
+func placeholderText(placeholder string, r rune) string {
+	return fmt.Sprintf(placeholder, puaToID(r))
+}
+
+// textareaRuneWidth returns the display width of a buffer rune.
+func textareaRuneWidth(placeholder string, r rune) int {
+	if isPUA(r) {
+		return runewidth.StringWidth(placeholderText(placeholder, r))
 	}
 
-	// Calculate cursor position within this line in the raw buffer
-	lineStart := 0
-	for i := 0; i < lineIdx; i++ {
-		idx := indexRune(buf[lineStart:], '\n')
-		if idx < 0 {
-			break
+	return runewidth.RuneWidth(r)
+}
+
+// textareaWidth returns the width available for content: the terminal width
+// of w minus the bar gutter and one column for a cursor at the end of a row.
+func textareaWidth(w Writer) int {
+	return max(getColumns(w)-4, 1)
+}
+
+// textareaHeight returns the number of rows to display for total rows of
+// content. When maxHeight is unset the viewport is bounded by the terminal
+// height of w, leaving room for the title and the closing bar.
+func textareaHeight(w Writer, maxHeight, total int) int {
+	height := maxHeight
+	if height <= 0 {
+		if rows := getRows(w); rows > 0 {
+			height = max(rows-5, 3)
+		}
+	}
+
+	if height <= 0 || height > total {
+		height = total
+	}
+
+	return height
+}
+
+// layoutTextarea soft-wraps the buffer into visual rows no wider than width.
+// Paste placeholders are never split across rows.
+func layoutTextarea(buf []rune, width int, placeholder string) []textareaRow {
+	var rows []textareaRow
+
+	start, w := 0, 0
+
+	for i, r := range buf {
+		if r == '\n' {
+			rows = append(rows, textareaRow{start: start, end: i, last: true})
+			start, w = i+1, 0
+
+			continue
 		}
 
-		lineStart += idx + 1
+		rw := textareaRuneWidth(placeholder, r)
+		if w+rw > width && i > start {
+			rows = append(rows, textareaRow{start: start, end: i})
+			start, w = i, 0
+		}
+
+		w += rw
+	}
+
+	return append(rows, textareaRow{start: start, end: len(buf), last: true})
+}
+
+// cursorRow returns the index of the row containing cursor. A cursor at a
+// soft-wrap boundary belongs to the following row.
+func cursorRow(rows []textareaRow, cursor int) int {
+	for i, row := range rows {
+		if cursor >= row.start && (cursor < row.end || (cursor == row.end && row.last)) {
+			return i
+		}
+	}
+
+	return len(rows) - 1
+}
+
+// moveVisualRow moves cursor delta rows up or down, keeping its display
+// column where possible.
+func moveVisualRow(buf []rune, rows []textareaRow, cursor, delta int, placeholder string) int {
+	from := cursorRow(rows, cursor)
+
+	target := from + delta
+	if target < 0 || target >= len(rows) {
+		return cursor
+	}
+
+	col := 0
+	for _, r := range buf[rows[from].start:cursor] {
+		col += textareaRuneWidth(placeholder, r)
+	}
+
+	return rowIndexAt(buf, rows[target], col, placeholder)
+}
+
+// rowIndexAt returns the buffer index at display column col of row, or the
+// row's last position when col is past its end.
+func rowIndexAt(buf []rune, row textareaRow, col int, placeholder string) int {
+	limit := row.end
+	if !row.last {
+		limit-- // the wrap position belongs to the next row
+	}
+
+	pos, w := row.start, 0
+	for pos < limit && w+textareaRuneWidth(placeholder, buf[pos]) <= col {
+		w += textareaRuneWidth(placeholder, buf[pos])
+		pos++
 	}
 
-	// Find raw line end
-	lineEnd := lineStart
+	return pos
+}
+
+// scrollToRow adjusts the first visible row so that row stays in view.
+func scrollToRow(top, row, height, total int) int {
+	if row < top {
+		top = row
+	}
+
+	if row >= top+height {
+		top = row - height + 1
+	}
+
+	return min(max(top, 0), max(total-height, 0))
+}
+
+// scrollThumb returns the offset and length of the scroll indicator within a
+// viewport of height rows showing total rows from top.
+func scrollThumb(top, height, total int) (start, length int) {
+	if total <= height {
+		return 0, 0
+	}
+
+	length = max(height*height/total, 1)
+	start = min(top*height/total, height-length)
+
+	if top+height >= total {
+		start = height - length
+	}
+
+	return start, length
+}
+
+// renderTextareaLine renders one visual row, replacing paste runes with dim
+// placeholders and drawing the cursor when it falls on this row. When
+// highlight is set the row is cut from the highlighted logical line instead.
+func renderTextareaLine(buf []rune, row textareaRow, cursor int, state ClackState, highlight func(string) string, placeholder string) string {
+	active := state == StateActive || state == StateInitial
+
+	if highlight != nil {
+		return renderHighlightedLine(buf, row, cursor, active, highlight, placeholder)
+	}
+
+	var b strings.Builder
+
+	for i := row.start; i < row.end; i++ {
+		r := buf[i]
+
+		switch {
+		case isPUA(r) && active && i == cursor:
+			// The placeholder is already styled; show the cursor after it
+			b.WriteString(dim(placeholderText(placeholder, r)) + inverse(" "))
+		case isPUA(r):
+			b.WriteString(dim(placeholderText(placeholder, r)))
+		case active && i == cursor:
+			b.WriteString(inverse(string(r)))
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	if active && cursor == row.end && row.last {
+		b.WriteString(inverse(" "))
+	}
+
+	return b.String()
+}
+
+// renderHighlightedLine passes the whole logical line containing row through
+// highlight, then keeps only the characters belonging to row. Styles opened
+// before the row or interrupted by the cursor are re-applied so that the
+// highlighting survives wrapping and cursor placement.
+func renderHighlightedLine(buf []rune, row textareaRow, cursor int, active bool, highlight func(string) string, placeholder string) string {
+	lineStart := lineStartIndex(buf, row.start)
+
+	lineEnd := row.end
 	for lineEnd < len(buf) && buf[lineEnd] != '\n' {
 		lineEnd++
 	}
 
-	if cursor < lineStart || cursor > lineEnd {
-		return line
-	}
+	// Expand placeholders and record where each buffer rune starts in the text
+	var text strings.Builder
+
+	offsets := make([]int, 0, lineEnd-lineStart+1)
+	n := 0
 
-	// Cursor is on this line. Map raw buffer position to display position.
-	// Walk the raw buffer from lineStart to cursor, tracking display offset
-	// in the rendered string (which has ANSI sequences for PUA runes).
-	posInLine := cursor - lineStart
+	for _, r := range buf[lineStart:lineEnd] {
+		offsets = append(offsets, n)
 
-	// Count how many runes (non-PUA) and PUA placeholders are before cursor position
-	// We need to map raw cursor offset to display string offset
-	displayOffset := 0
-	for i := lineStart; i < lineStart+posInLine && i < len(buf); i++ {
-		if isPUA(buf[i]) {
-			// PUA rune renders as dim("[Text N]") which includes ANSI codes
-			placeholder := dim(fmt.Sprintf("[Text %d]", puaToID(buf[i])))
-			displayOffset += len(placeholder)
+		if isPUA(r) {
+			label := placeholderText(placeholder, r)
+			text.WriteString(label)
+			n += utf8.RuneCountInString(label)
 		} else {
-			displayOffset += len(string(buf[i]))
+			text.WriteRune(r)
+			n++
 		}
 	}
 
-	// Now split the rendered line at displayOffset (byte offset)
-	if displayOffset >= len(line) {
-		return line + inverse(" ")
+	offsets = append(offsets, n)
+
+	from, to := offsets[row.start-lineStart], offsets[row.end-lineStart]
+
+	// on is the character drawn inverted; after is where a block cursor is appended
+	on, after := -1, -1
+
+	if active && cursor >= row.start && cursor <= row.end {
+		switch {
+		case cursor == row.end:
+			if row.last {
+				after = to
+			}
+		case isPUA(buf[cursor]):
+			after = offsets[cursor-lineStart+1]
+		default:
+			on = offsets[cursor-lineStart]
+		}
 	}
 
-	before := line[:displayOffset]
-	after := line[displayOffset:]
+	hl := highlight(text.String())
+
+	var (
+		b      strings.Builder
+		styles []string // SGR sequences in effect since the last reset
+	)
+
+	pos := 0
+	styled := false
 
-	// Extract the first rune/token at cursor position for inverse rendering
-	// If cursor is on a PUA rune, the placeholder is already styled, just add cursor after
-	if posInLine < lineEnd-lineStart && isPUA(buf[lineStart+posInLine]) {
-		// Cursor is at a PUA rune position — render cursor after the placeholder
-		placeholder := dim(fmt.Sprintf("[Text %d]", puaToID(buf[lineStart+posInLine])))
-		rest := line[displayOffset+len(placeholder):]
+	for idx := 0; idx < len(hl); {
+		token, _, next := scanANSIToken(hl, idx)
+		idx = next
 
-		return before + placeholder + inverse(" ") + rest
+		if token[0] == '\x1b' {
+			if token == Reset || token == "\x1b[m" {
+				styles = nil
+			} else {
+				styles = append(styles, token)
+			}
+
+			if pos > from && pos < to {
+				b.WriteString(token)
+				styled = true
+			}
+
+			continue
+		}
+
+		if pos == from && len(styles) > 0 {
+			b.WriteString(strings.Join(styles, ""))
+			styled = true
+		}
+
+		if pos >= from && pos < to {
+			if pos == on {
+				b.WriteString(inverse(token) + strings.Join(styles, ""))
+			} else {
+				b.WriteString(token)
+			}
+		}
+
+		pos++
+
+		if pos == after && after < to {
+			b.WriteString(Reset + inverse(" ") + strings.Join(styles, ""))
+		}
 	}
 
-	// Regular rune at cursor — apply inverse to the first rune
-	firstRune := []rune(after)
-	if len(firstRune) == 0 {
-		return line + inverse(" ")
+	if styled {
+		b.WriteString(Reset)
 	}
 
-	charStr := string(firstRune[0])
-	remaining := after[len(charStr):]
+	if after == to {
+		b.WriteString(inverse(" "))
+	}
 
-	return before + inverse(charStr) + remaining
+	return b.String()
 }
 
-// indexRune returns the index of the first occurrence of r in s, or -1.
-func indexRune(s []rune, r rune) int {
-	for i, v := range s {
-		if v == r {
-			return i
+// truncateText cuts s to at most maxRunes runes and maxNewlines newlines,
+// reporting whether anything was dropped. Negative limits are unlimited.
+func truncateText(s string, maxRunes, maxNewlines int) (string, bool) {
+	runes := []rune(s)
+
+	n := 0
+	for i, r := range runes {
+		if i == maxRunes || (r == '\n' && n == maxNewlines) {
+			return string(runes[:i]), true
+		}
+
+		if r == '\n' {
+			n++
 		}
 	}
 
-	return -1
+	return s, false
+}
+
+// lineStartIndex returns the index of the first rune of the logical line containing cursor.
+func lineStartIndex(buf []rune, cursor int) int {
+	for cursor > 0 && buf[cursor-1] != '\n' {
+		cursor--
+	}
+
+	return cursor
 }
 
 // cursorToLineCol converts a flat cursor index into line and column numbers.
@@ -3,9 +3,15 @@ package tap
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/yarlson/tap/internal/terminal"
 )
 
 func TestTextarea_BasicInput(t *testing.T) {
@@ -516,30 +522,19 @@ func TestTextarea_RendersBarsPerLine(t *testing.T) {
 
 	time.Sleep(5 * time.Millisecond)
 
-	// Check frames for bar prefix on each content line
-	frames := out.GetFrames()
-	found := false
+	// Check the screen for bar prefix on each content line
+	frame := screenOf(out)
+	barCount := 0
 
-	for _, frame := range frames {
-		// A multiline frame should have at least two lines with Bar prefix containing content
-		if strings.Contains(frame, "first") && strings.Contains(frame, "second") {
-			lines := strings.Split(frame, "\n")
-			barCount := 0
-
-			for _, line := range lines {
-				if strings.Contains(line, Bar) {
-					barCount++
-				}
-			}
-
-			// Should have at least 3 bars: separator, first line, second line
-			if barCount >= 3 {
-				found = true
-				break
-			}
+	for _, line := range strings.Split(frame, "\n") {
+		if strings.Contains(line, Bar) {
+			barCount++
 		}
 	}
 
+	// Should have at least 3 bars: separator, first line, second line
+	found := strings.Contains(frame, "first") && strings.Contains(frame, "second") && barCount >= 3
+
 	if !found {
 		t.Error("expected bar prefix on each content line in multiline frame")
 	}
@@ -1705,3 +1700,577 @@ func TestTextarea_ShiftReturnClearsError(t *testing.T) {
 		t.Fatalf("expected %q, got %q", "hi\njkl", got)
 	}
 }
+
+func TestTextarea_CtrlEOpensEditorWithResolvedContent(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake editor is a shell script")
+	}
+
+	dir := t.TempDir()
+	seen := filepath.Join(dir, "seen.txt")
+	script := filepath.Join(dir, "editor.sh")
+
+	// The fake editor records what it was given and replaces the content.
+	body := "#!/bin/sh\ncp \"$1\" " + seen + "\nprintf 'edited\\nin editor\\n' > \"$1\"\n"
+	if err := os.WriteFile(script, []byte(body), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	resultCh := make(chan string, 1)
+
+	go func() {
+		resultCh <- Textarea(context.Background(), TextareaOptions{
+			Message: "Commit message:",
+			Editor:  "sh " + script,
+			Input:   in,
+			Output:  out,
+		})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	in.EmitKeypress("a", Key{Name: "a", Rune: 'a'})
+	in.EmitPaste("pasted\ntext")
+	in.EmitKeypress("", Key{Name: "e", Ctrl: true})
+	time.Sleep(50 * time.Millisecond)
+
+	got, err := os.ReadFile(seen)
+	if err != nil {
+		t.Fatalf("editor was not run: %v", err)
+	}
+
+	if string(got) != "apasted\ntext" {
+		t.Errorf("expected editor to receive resolved buffer, got %q", got)
+	}
+
+	in.EmitKeypress("!", Key{Name: "!", Rune: '!'})
+	in.EmitKeypress("", Key{Name: "return"})
+
+	if res := <-resultCh; res != "edited\nin editor!" {
+		t.Fatalf("expected edited content, got %q", res)
+	}
+}
+
+func TestTextarea_CtrlEShowsEditorFailure(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	resultCh := make(chan string, 1)
+
+	go func() {
+		resultCh <- Textarea(context.Background(), TextareaOptions{
+			Message:      "Notes:",
+			InitialValue: "keep me",
+			Editor:       filepath.Join(t.TempDir(), "missing-editor"),
+			Input:        in,
+			Output:       out,
+		})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	in.EmitKeypress("", Key{Name: "e", Ctrl: true})
+	time.Sleep(20 * time.Millisecond)
+
+	frames := out.GetFrames()
+	if last := frames[len(frames)-1]; !strings.Contains(last, "missing-editor") || !strings.Contains(last, StepError) {
+		t.Errorf("expected editor error in frame, got %q", last)
+	}
+
+	in.EmitKeypress("", Key{Name: "return"})
+
+	if res := <-resultCh; res != "keep me" {
+		t.Fatalf("expected buffer to be kept, got %q", res)
+	}
+}
+
+func TestOpenInEditor_UsesControllingTerminal(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake editor is a shell script")
+	}
+
+	dir := t.TempDir()
+	script := filepath.Join(dir, "editor.sh")
+
+	// The fake editor echoes a line of input where it would draw its UI.
+	body := "#!/bin/sh\nread line\necho \"ui:$line\"\n"
+	if err := os.WriteFile(script, []byte(body), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	ttyIn := filepath.Join(dir, "tty-in")
+	if err := os.WriteFile(ttyIn, []byte("typed\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	ttyOut := filepath.Join(dir, "tty-out")
+
+	origIn, origOut := openTTYInput, openTTYOutput
+	defer func() { openTTYInput, openTTYOutput = origIn, origOut }()
+
+	openTTYInput = func() (*os.File, error) { return os.Open(ttyIn) }
+	openTTYOutput = func() (*os.File, error) { return os.Create(ttyOut) }
+
+	// Neither stdin nor stdout is a terminal, as when both are redirected
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _ = r.Close(); _ = w.Close() }()
+
+	stdin, output := os.Stdin, terminal.Output()
+	defer func() { os.Stdin = stdin; terminal.SetOutput(output) }()
+
+	os.Stdin = r
+
+	stdout := capture(t, &os.Stdout, func() {
+		terminal.SetOutput(os.Stdout)
+
+		if _, err := openInEditor("sh "+script, "", nil, NewMockWritable()); err != nil {
+			t.Errorf("editor failed: %v", err)
+		}
+	})
+
+	if stdout != "" {
+		t.Errorf("expected nothing on stdout, got %q", stdout)
+	}
+
+	if got, _ := os.ReadFile(ttyOut); string(got) != "ui:typed\n" {
+		t.Errorf("expected the editor on the controlling terminal, got %q", got)
+	}
+}
+
+func TestEditorCommand(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "code --wait")
+
+	if got := editorCommand(""); !slices.Equal(got, []string{"code", "--wait"}) {
+		t.Errorf("expected $EDITOR, got %v", got)
+	}
+
+	t.Setenv("VISUAL", "nvim")
+
+	if got := editorCommand(""); !slices.Equal(got, []string{"nvim"}) {
+		t.Errorf("expected $VISUAL to win, got %v", got)
+	}
+
+	if got := editorCommand("nano -w"); !slices.Equal(got, []string{"nano", "-w"}) {
+		t.Errorf("expected override to win, got %v", got)
+	}
+
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+
+	if got := editorCommand(""); !slices.Equal(got, []string{"vi"}) {
+		t.Errorf("expected vi fallback, got %v", got)
+	}
+}
+
+func TestLayoutTextarea_SoftWraps(t *testing.T) {
+	buf := []rune("abcdefg\nhi")
+
+	rows := layoutTextarea(buf, 3, "[Text %d]")
+	want := []textareaRow{
+		{start: 0, end: 3},
+		{start: 3, end: 6},
+		{start: 6, end: 7, last: true},
+		{start: 8, end: 10, last: true},
+	}
+
+	if !slices.Equal(rows, want) {
+		t.Fatalf("expected %v, got %v", want, rows)
+	}
+
+	// The wrap position belongs to the following row
+	if got := cursorRow(rows, 3); got != 1 {
+		t.Errorf("expected cursor 3 on row 1, got %d", got)
+	}
+
+	if got := cursorRow(rows, 7); got != 2 {
+		t.Errorf("expected cursor 7 on row 2, got %d", got)
+	}
+}
+
+func TestLayoutTextarea_KeepsPlaceholdersWhole(t *testing.T) {
+	buf := []rune{'a', 'b', idToPUA(1), 'c'}
+
+	rows := layoutTextarea(buf, 9, "[Text %d]")
+	if len(rows) != 2 || rows[0].end != 2 || rows[1].start != 2 {
+		t.Fatalf("expected placeholder to start a new row, got %v", rows)
+	}
+}
+
+func TestMoveVisualRow_KeepsColumn(t *testing.T) {
+	buf := []rune("abcdefg\nhi")
+	rows := layoutTextarea(buf, 3, "[Text %d]")
+
+	if got := moveVisualRow(buf, rows, 4, -1, "[Text %d]"); got != 1 {
+		t.Errorf("expected up from 4 to land on 1, got %d", got)
+	}
+
+	// Moving onto a shorter row clamps to its end
+	if got := moveVisualRow(buf, rows, 5, 1, "[Text %d]"); got != 7 {
+		t.Errorf("expected down from 5 to clamp to 7, got %d", got)
+	}
+
+	// A wrapped row clamps before its wrap position
+	if got := moveVisualRow(buf, rows, 10, -3, "[Text %d]"); got != 2 {
+		t.Errorf("expected clamp to 2, got %d", got)
+	}
+
+	if got := moveVisualRow(buf, rows, 1, -1, "[Text %d]"); got != 1 {
+		t.Errorf("expected cursor to stay on first row, got %d", got)
+	}
+}
+
+func TestTextarea_UpMovesByVisualRow(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	// Longer than one row at the default width, so it soft-wraps
+	initial := strings.Repeat("a", textareaWidth(out)+10)
+
+	resultCh := make(chan string, 1)
+
+	go func() {
+		resultCh <- Textarea(context.Background(), TextareaOptions{
+			Message:      "Enter text:",
+			InitialValue: initial,
+			Input:        in,
+			Output:       out,
+		})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	in.EmitKeypress("", Key{Name: "up"})
+	in.EmitKeypress("x", Key{Name: "x", Rune: 'x'})
+	time.Sleep(5 * time.Millisecond)
+
+	frames := out.GetFrames()
+	for _, line := range strings.Split(frames[len(frames)-1], "\n") {
+		if w := visibleWidth(line); w > getColumns(out) {
+			t.Errorf("expected rows to fit the terminal, got width %d: %q", w, line)
+		}
+	}
+
+	in.EmitKeypress("", Key{Name: "return"})
+
+	want := initial[:10] + "x" + initial[10:]
+	if got := <-resultCh; got != want {
+		t.Fatalf("expected x inserted one visual row up, got %q", got)
+	}
+}
+
+func TestTextarea_MaxHeightScrollsWithCursor(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	lines := make([]string, 10)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d", i)
+	}
+
+	resultCh := make(chan string, 1)
+
+	go func() {
+		resultCh <- Textarea(context.Background(), TextareaOptions{
+			Message:      "Enter text:",
+			InitialValue: strings.Join(lines, "\n"),
+			MaxHeight:    3,
+			Input:        in,
+			Output:       out,
+		})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	in.EmitKeypress("", Key{Name: "up"})
+	time.Sleep(5 * time.Millisecond)
+
+	frame := removeANSI(screenOf(out))
+	for _, want := range []string{"line 7", "line 8", "line 9", ScrollThumb} {
+		if !strings.Contains(frame, want) {
+			t.Errorf("expected %q in viewport, got %q", want, frame)
+		}
+	}
+
+	if strings.Contains(frame, "line 6") {
+		t.Errorf("expected line 6 to be scrolled out, got %q", frame)
+	}
+
+	// Moving above the viewport scrolls it up
+	for range 3 {
+		in.EmitKeypress("", Key{Name: "up"})
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	frame = removeANSI(screenOf(out))
+	if !strings.Contains(frame, "line 5") || strings.Contains(frame, "line 8") {
+		t.Errorf("expected viewport to follow the cursor, got %q", frame)
+	}
+
+	in.EmitKeypress("", Key{Name: "escape"})
+	<-resultCh
+}
+
+func TestTextarea_TabIndentsAndOutdentsLine(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	resultCh := make(chan string, 1)
+
+	go func() {
+		resultCh <- Textarea(context.Background(), TextareaOptions{
+			Message:      "YAML:",
+			InitialValue: "a:\nb",
+			Input:        in,
+			Output:       out,
+		})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	in.EmitKeypress("", Key{Name: "tab"})
+	in.EmitKeypress("", Key{Name: "tab"})
+	in.EmitKeypress("", Key{Name: "tab", Shift: true})
+	in.EmitKeypress("c", Key{Name: "c", Rune: 'c'})
+	in.EmitKeypress("", Key{Name: "return"})
+
+	if got := <-resultCh; got != "a:\n  bc" {
+		t.Fatalf("expected indented line, got %q", got)
+	}
+}
+
+func TestTextarea_ShiftReturnAutoIndents(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	resultCh := make(chan string, 1)
+
+	go func() {
+		resultCh <- Textarea(context.Background(), TextareaOptions{
+			Message:      "YAML:",
+			InitialValue: "root:\n    key: 1",
+			Input:        in,
+			Output:       out,
+		})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	in.EmitKeypress("", Key{Name: "return", Shift: true})
+	in.EmitKeypress("x", Key{Name: "x", Rune: 'x'})
+	in.EmitKeypress("", Key{Name: "return"})
+
+	if got := <-resultCh; got != "root:\n    key: 1\n    x" {
+		t.Fatalf("expected indentation to carry over, got %q", got)
+	}
+}
+
+func TestTextarea_LineNumbers(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	lines := make([]string, 10)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("row%d", i)
+	}
+
+	resultCh := make(chan string, 1)
+
+	go func() {
+		resultCh <- Textarea(context.Background(), TextareaOptions{
+			Message:      "SQL:",
+			InitialValue: strings.Join(lines, "\n"),
+			LineNumbers:  true,
+			Input:        in,
+			Output:       out,
+		})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	in.EmitKeypress("", Key{Name: "escape"})
+	<-resultCh
+
+	var frame string
+
+	for _, f := range out.GetFrames() {
+		if strings.Contains(f, "row9") && !strings.Contains(f, StepCancel) {
+			frame = removeANSI(f)
+		}
+	}
+
+	for _, want := range []string{Bar + "   1 row0", Bar + "  10 row9"} {
+		if !strings.Contains(frame, want) {
+			t.Errorf("expected %q in frame, got %q", want, frame)
+		}
+	}
+}
+
+func TestRenderTextareaLine_HighlightKeepsCursor(t *testing.T) {
+	keyword := func(line string) string {
+		return strings.ReplaceAll(line, "SELECT", Red+"SELECT"+Reset)
+	}
+
+	buf := []rune("SELECT 1")
+	row := textareaRow{start: 0, end: len(buf), last: true}
+
+	got := renderTextareaLine(buf, row, 2, StateActive, keyword, "[Text %d]")
+	if removeANSI(got) != "SELECT 1" {
+		t.Fatalf("expected text to be preserved, got %q", removeANSI(got))
+	}
+
+	// The keyword color is re-applied after the inverted cursor character
+	if !strings.Contains(got, Red+"SE"+inverse("L")+Red+"ECT") {
+		t.Errorf("expected cursor inside highlighted keyword, got %q", got)
+	}
+
+	got = renderTextareaLine(buf, row, len(buf), StateActive, keyword, "[Text %d]")
+	if !strings.HasSuffix(got, inverse(" ")) {
+		t.Errorf("expected block cursor at end, got %q", got)
+	}
+}
+
+func TestRenderTextareaLine_HighlightAcrossWrap(t *testing.T) {
+	upper := func(line string) string { return Cyan + line + Reset }
+
+	buf := []rune("abcdef")
+	rows := layoutTextarea(buf, 3, "[Text %d]")
+
+	got := renderTextareaLine(buf, rows[1], 0, StateSubmit, upper, "[Text %d]")
+	if got != Cyan+"def"+Reset {
+		t.Errorf("expected style to carry into wrapped row, got %q", got)
+	}
+}
+
+func TestTextarea_MaxLengthTruncatesPaste(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	resultCh := make(chan string, 1)
+
+	go func() {
+		resultCh <- Textarea(context.Background(), TextareaOptions{
+			Message:   "Description:",
+			MaxLength: 8,
+			Input:     in,
+			Output:    out,
+		})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	in.EmitKeypress("a", Key{Name: "a", Rune: 'a'})
+	in.EmitPaste("0123456789")
+	time.Sleep(5 * time.Millisecond)
+
+	frames := out.GetFrames()
+
+	last := frames[len(frames)-1]
+	if !strings.Contains(last, yellow("8/8")) || !strings.Contains(last, "Paste truncated") {
+		t.Errorf("expected counter and truncation notice, got %q", last)
+	}
+
+	in.EmitKeypress("b", Key{Name: "b", Rune: 'b'})
+	in.EmitKeypress("", Key{Name: "return"})
+
+	if got := <-resultCh; got != "a0123456" {
+		t.Fatalf("expected paste truncated to the limit, got %q", got)
+	}
+}
+
+func TestTextarea_MaxLinesBlocksNewlines(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	resultCh := make(chan string, 1)
+
+	go func() {
+		resultCh <- Textarea(context.Background(), TextareaOptions{
+			Message:  "Description:",
+			MaxLines: 2,
+			Input:    in,
+			Output:   out,
+		})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	in.EmitKeypress("a", Key{Name: "a", Rune: 'a'})
+	in.EmitKeypress("", Key{Name: "return", Shift: true})
+	in.EmitKeypress("", Key{Name: "return", Shift: true})
+	in.EmitPaste("b\nc")
+	time.Sleep(5 * time.Millisecond)
+
+	frames := out.GetFrames()
+	if last := frames[len(frames)-1]; !strings.Contains(last, yellow("2/2 lines")) {
+		t.Errorf("expected line counter, got %q", last)
+	}
+
+	in.EmitKeypress("", Key{Name: "return"})
+
+	if got := <-resultCh; got != "a\nb" {
+		t.Fatalf("expected at most two lines, got %q", got)
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	tests := []struct {
+		s                     string
+		maxRunes, maxNewlines int
+		want                  string
+		cut                   bool
+	}{
+		{"hello", -1, -1, "hello", false},
+		{"hello", 3, -1, "hel", true},
+		{"a\nb\nc", -1, 1, "a\nb", true},
+		{"a\nb", 10, 1, "a\nb", false},
+		{"héllo", 2, -1, "hé", true},
+	}
+
+	for _, tt := range tests {
+		got, cut := truncateText(tt.s, tt.maxRunes, tt.maxNewlines)
+		if got != tt.want || cut != tt.cut {
+			t.Errorf("truncateText(%q, %d, %d) = (%q, %v), want (%q, %v)", tt.s, tt.maxRunes, tt.maxNewlines, got, cut, tt.want, tt.cut)
+		}
+	}
+}
+
+func TestTextarea_ViewportFollowsWriterSize(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+	out.SetSize(40, 8) // room for three rows of content
+
+	lines := make([]string, 10)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d", i)
+	}
+
+	resultCh := make(chan string, 1)
+
+	go func() {
+		resultCh <- Textarea(context.Background(), TextareaOptions{
+			Message:      "Enter text:",
+			InitialValue: strings.Join(lines, "\n"),
+			Input:        in,
+			Output:       out,
+		})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+
+	frame := removeANSI(screenOf(out))
+	if strings.Contains(frame, "line 6") || !strings.Contains(frame, "line 7") {
+		t.Errorf("expected a three row viewport, got %q", frame)
+	}
+
+	// Growing the terminal shows more rows
+	out.SetSize(40, 10)
+	time.Sleep(5 * time.Millisecond)
+
+	frame = removeANSI(screenOf(out))
+	if !strings.Contains(frame, "line 5") {
+		t.Errorf("expected the viewport to grow, got %q", frame)
+	}
+
+	in.EmitKeypress("", Key{Name: "return"})
+	<-resultCh
+}
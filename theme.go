@@ -0,0 +1,272 @@
+package tap
+
+import (
+	"os"
+	"runtime"
+	"strings"
+	"sync/atomic"
+
+	xterm "golang.org/x/term"
+
+	"github.com/yarlson/tap/internal/terminal"
+)
+
+// Theme describes the glyphs and colors used to draw prompts and utilities.
+// Colors are ANSI SGR sequences such as "\033[96m" or "\033[38;2;255;95;0m";
+// an empty color leaves the text unstyled.
+//
+// The global theme is set with SetTheme; every options struct also accepts a
+// *Theme that overrides it for a single prompt or utility.
+type Theme struct {
+	// State symbols.
+	StepActive string
+	StepCancel string
+	StepError  string
+	StepSubmit string
+
+	// Bar glyphs.
+	Bar           string
+	BarH          string
+	BarStart      string
+	BarStartRight string
+	BarEnd        string
+	BarEndRight   string
+	ScrollThumb   string
+
+	// Radio and checkbox glyphs.
+	RadioActive       string
+	RadioInactive     string
+	CheckboxChecked   string
+	CheckboxUnchecked string
+
+	// Rounded box corners.
+	CornerTopLeft     string
+	CornerTopRight    string
+	CornerBottomLeft  string
+	CornerBottomRight string
+
+	// Table borders.
+	TableTopLeft     string
+	TableTopRight    string
+	TableBottomLeft  string
+	TableBottomRight string
+	TableTopTee      string
+	TableBottomTee   string
+	TableLeftTee     string
+	TableRightTee    string
+	TableCross       string
+	TableHorizontal  string
+	TableVertical    string
+
+	// Spinner, progress and password glyphs.
+	SpinnerFrames  []string // default spinner and progress animation
+	ProgressFilled string   // overrides the progress style character when set
+	ProgressEmpty  string   // overrides the progress style character when set
+	PasswordMask   rune     // default password and secret mask
+	MeterSegment   string   // one segment of the password strength meter
+
+	// Color roles.
+	ActiveColor  string // active prompt bars and symbols, spinners, highlights
+	SuccessColor string // submitted prompts, selected options, completed progress
+	WarningColor string // validation errors and values near a limit
+	DangerColor  string // cancellation and failures
+	MutedColor   string // inactive bars, guides and hints
+}
+
+// DefaultTheme returns the built-in theme.
+func DefaultTheme() *Theme {
+	return &Theme{
+		StepActive: StepActive,
+		StepCancel: StepCancel,
+		StepError:  StepError,
+		StepSubmit: StepSubmit,
+
+		Bar:           Bar,
+		BarH:          BarH,
+		BarStart:      BarStart,
+		BarStartRight: BarStartRight,
+		BarEnd:        BarEnd,
+		BarEndRight:   BarEndRight,
+		ScrollThumb:   ScrollThumb,
+
+		RadioActive:       RadioActive,
+		RadioInactive:     RadioInactive,
+		CheckboxChecked:   CheckboxChecked,
+		CheckboxUnchecked: CheckboxUnchecked,
+
+		CornerTopLeft:     CornerTopLeft,
+		CornerTopRight:    CornerTopRight,
+		CornerBottomLeft:  CornerBottomLeft,
+		CornerBottomRight: CornerBottomRight,
+
+		TableTopLeft:     TableTopLeft,
+		TableTopRight:    TableTopRight,
+		TableBottomLeft:  TableBottomLeft,
+		TableBottomRight: TableBottomRight,
+		TableTopTee:      TableTopTee,
+		TableBottomTee:   TableBottomTee,
+		TableLeftTee:     TableLeftTee,
+		TableRightTee:    TableRightTee,
+		TableCross:       TableCross,
+		TableHorizontal:  TableHorizontal,
+		TableVertical:    TableVertical,
+
+		SpinnerFrames: []string{"◒", "◐", "◓", "◑"},
+		PasswordMask:  defaultPasswordMask,
+		MeterSegment:  "━━",
+
+		ActiveColor:  Cyan,
+		SuccessColor: Green,
+		WarningColor: Yellow,
+		DangerColor:  Red,
+		MutedColor:   Gray,
+	}
+}
+
+// ASCIITheme returns a theme that draws only with ASCII characters, for
+// serial consoles and terminals without Unicode fonts. Every glyph is one
+// column wide except the bracketed radio and checkbox markers.
+func ASCIITheme() *Theme {
+	t := DefaultTheme()
+
+	t.StepActive = "*"
+	t.StepCancel = "x"
+	t.StepError = "!"
+	t.StepSubmit = "o"
+
+	t.Bar = "|"
+	t.BarH = "-"
+	t.BarStart = "+"
+	t.BarStartRight = "+"
+	t.BarEnd = "+"
+	t.BarEndRight = "+"
+	t.ScrollThumb = "#"
+
+	t.RadioActive = "(*)"
+	t.RadioInactive = "( )"
+	t.CheckboxChecked = "[x]"
+	t.CheckboxUnchecked = "[ ]"
+
+	t.CornerTopLeft = "+"
+	t.CornerTopRight = "+"
+	t.CornerBottomLeft = "+"
+	t.CornerBottomRight = "+"
+
+	t.TableTopLeft = "+"
+	t.TableTopRight = "+"
+	t.TableBottomLeft = "+"
+	t.TableBottomRight = "+"
+	t.TableTopTee = "+"
+	t.TableBottomTee = "+"
+	t.TableLeftTee = "+"
+	t.TableRightTee = "+"
+	t.TableCross = "+"
+	t.TableHorizontal = "-"
+	t.TableVertical = "|"
+
+	t.SpinnerFrames = []string{"|", "/", "-", "\\"}
+	t.ProgressFilled = "#"
+	t.ProgressEmpty = "-"
+	t.PasswordMask = '*'
+	t.MeterSegment = "=="
+
+	return t
+}
+
+// UnicodeSupported reports whether the terminal described by the environment
+// is likely to render the default Unicode glyphs. It returns false for
+// serial-console and legacy terminal types, for a locale without UTF-8, and
+// for the classic Windows console host.
+func UnicodeSupported() bool {
+	switch os.Getenv("TERM") {
+	case "linux", "vt100", "vt102", "vt220", "vt320", "ansi", "dumb":
+		return false
+	}
+
+	for _, key := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if locale := os.Getenv(key); locale != "" {
+			locale = strings.ToLower(locale)
+			return strings.Contains(locale, "utf-8") || strings.Contains(locale, "utf8")
+		}
+	}
+
+	if runtime.GOOS == "windows" {
+		// Windows Terminal and terminals that set TERM_PROGRAM handle Unicode;
+		// the legacy console host does not.
+		return os.Getenv("WT_SESSION") != "" || os.Getenv("TERM_PROGRAM") != "" || os.Getenv("TERM") != ""
+	}
+
+	return true
+}
+
+// detectTheme returns the ASCII theme when the output is a terminal that
+// cannot render Unicode glyphs, and the default theme otherwise.
+func detectTheme() *Theme {
+	if xterm.IsTerminal(int(terminal.Output().Fd())) && !UnicodeSupported() {
+		return ASCIITheme()
+	}
+
+	return DefaultTheme()
+}
+
+// globalTheme is the theme set with SetTheme; nil selects detectedTheme.
+var globalTheme atomic.Pointer[Theme]
+
+// detectedTheme is the default theme for the current output. It is detected
+// again whenever the output target changes.
+var detectedTheme atomic.Pointer[Theme]
+
+func init() { detectedTheme.Store(detectTheme()) }
+
+// SetTheme replaces the global theme. Passing nil restores the default,
+// including the automatic ASCII fallback. Prompts and utilities that are
+// already rendering pick up the new theme on their next frame.
+func SetTheme(t *Theme) { globalTheme.Store(t) }
+
+// CurrentTheme returns the global theme.
+func CurrentTheme() *Theme { return resolveTheme(nil) }
+
+// resolveTheme returns t, or the global theme when t is nil.
+func resolveTheme(t *Theme) *Theme {
+	if t != nil {
+		return t
+	}
+
+	if t := globalTheme.Load(); t != nil {
+		return t
+	}
+
+	return detectedTheme.Load()
+}
+
+// paint wraps s in color, leaving it unstyled when color is empty.
+func paint(color, s string) string {
+	if color == "" {
+		return s
+	}
+
+	return color + s + Reset
+}
+
+// Color role helpers.
+func (t *Theme) active(s string) string  { return paint(t.ActiveColor, s) }
+func (t *Theme) success(s string) string { return paint(t.SuccessColor, s) }
+func (t *Theme) warning(s string) string { return paint(t.WarningColor, s) }
+func (t *Theme) danger(s string) string  { return paint(t.DangerColor, s) }
+func (t *Theme) muted(s string) string   { return paint(t.MutedColor, s) }
+
+// Symbol returns the colored state symbol for state.
+func (t *Theme) Symbol(state ClackState) string {
+	switch state {
+	case StateInitial, StateActive:
+		return t.active(t.StepActive)
+	case StateCancel:
+		return t.danger(t.StepCancel)
+	case StateError:
+		return t.warning(t.StepError)
+	case StateSubmit:
+		return t.success(t.StepSubmit)
+	}
+
+	return t.StepActive
+}
@@ -0,0 +1,185 @@
+package tap
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTheme_PerPromptOverride(t *testing.T) {
+	in := NewMockReadable()
+	out := NewMockWritable()
+
+	theme := DefaultTheme()
+	theme.StepActive = ">"
+	theme.Bar = "|"
+	theme.ActiveColor = "\033[38;2;255;95;0m"
+
+	done := make(chan string, 1)
+
+	go func() {
+		done <- Text(context.Background(), TextOptions{
+			Message: "Name:",
+			Theme:   theme,
+			Input:   in,
+			Output:  out,
+		})
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	in.EmitKeypress("", Key{Name: "escape"})
+	<-done
+
+	var frame string
+
+	for _, f := range out.GetFrames() {
+		if strings.Contains(f, "Name:") {
+			frame = f
+			break
+		}
+	}
+
+	if !strings.Contains(frame, theme.ActiveColor+">"+Reset) || !strings.Contains(frame, theme.ActiveColor+"|"+Reset) {
+		t.Errorf("expected themed symbol and bar, got %q", frame)
+	}
+
+	if strings.Contains(frame, Cyan) || strings.Contains(frame, StepActive) {
+		t.Errorf("expected default styling to be replaced, got %q", frame)
+	}
+}
+
+func TestSetTheme_AppliesGlobally(t *testing.T) {
+	theme := DefaultTheme()
+	theme.StepSubmit = "✔"
+	theme.SuccessColor = ""
+
+	SetTheme(theme)
+	defer SetTheme(nil)
+
+	out := NewMockWritable()
+	Message("Done", MessageOptions{Output: out})
+
+	if got := strings.Join(out.Buffer, ""); !strings.Contains(got, "✔  ") {
+		t.Errorf("expected global theme symbol, got %q", got)
+	}
+
+	// Per-call themes take precedence over the global one
+	out = NewMockWritable()
+	Message("Done", MessageOptions{Output: out, Theme: DefaultTheme()})
+
+	if got := strings.Join(out.Buffer, ""); !strings.Contains(got, Green+StepSubmit+Reset) {
+		t.Errorf("expected option theme to win, got %q", got)
+	}
+}
+
+func TestSetTheme_NilRestoresDefault(t *testing.T) {
+	SetTheme(&Theme{StepActive: "*"})
+	SetTheme(nil)
+
+	if got := Symbol(StateActive); got != Cyan+StepActive+Reset {
+		t.Errorf("expected default symbol, got %q", got)
+	}
+}
+
+func TestTheme_EmptyColorIsUnstyled(t *testing.T) {
+	theme := &Theme{StepError: "!"}
+
+	if got := theme.Symbol(StateError); got != "!" {
+		t.Errorf("expected unstyled symbol, got %q", got)
+	}
+}
+
+func TestASCIITheme_BoxAndTable(t *testing.T) {
+	out := NewMockWritable()
+	theme := ASCIITheme()
+
+	Box("hello", "Title", BoxOptions{Output: out, Columns: 20, Rounded: true, Theme: theme})
+	Table([]string{"Name", "Age"}, [][]string{{"Ann", "30"}}, TableOptions{Output: out, ShowBorders: true, Theme: theme})
+
+	var lines []string
+
+	for _, line := range strings.Split(stripANSI(strings.Join(out.Buffer, "")), "\n") {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	for _, line := range lines {
+		for _, r := range line {
+			if r > 127 {
+				t.Fatalf("expected ASCII output, got %q", line)
+			}
+		}
+	}
+
+	if lines[0] != "+Title"+strings.Repeat("-", 13)+"+" || lines[2] != "+"+strings.Repeat("-", 18)+"+" {
+		t.Errorf("unexpected box borders: %q", lines[:3])
+	}
+
+	if !strings.HasPrefix(lines[3], "+-") || !strings.Contains(lines[4], "| Name") {
+		t.Errorf("unexpected table borders: %q", lines[3:])
+	}
+
+	// Borders stay aligned with the content rows.
+	for _, line := range lines[3:] {
+		if visibleWidth(line) != visibleWidth(lines[3]) {
+			t.Errorf("expected table rows of equal width, got %q", lines[3:])
+			break
+		}
+	}
+}
+
+func TestASCIITheme_SpinnerAndProgress(t *testing.T) {
+	out := NewMockWritable()
+
+	s := NewSpinner(SpinnerOptions{Output: out, Theme: ASCIITheme()})
+	s.Start("Working")
+	s.Stop("Done", 0)
+
+	p := NewProgress(ProgressOptions{Output: out, Size: 4, Theme: ASCIITheme()})
+	p.Start("Copying")
+	p.Advance(50, "")
+	p.Stop("Copied", 0)
+
+	got := stripANSI(strings.Join(out.Buffer, ""))
+
+	if !strings.Contains(got, "|  Working") {
+		t.Errorf("expected ASCII spinner frame, got %q", got)
+	}
+
+	if !strings.Contains(got, "##--") {
+		t.Errorf("expected ASCII progress bar, got %q", got)
+	}
+
+	if strings.ContainsAny(got, "◒━│◇") {
+		t.Errorf("expected no Unicode glyphs, got %q", got)
+	}
+}
+
+func TestUnicodeSupported(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want bool
+	}{
+		{"utf8 locale", map[string]string{"TERM": "xterm-256color", "LANG": "en_US.UTF-8"}, true},
+		{"utf8 lowercase", map[string]string{"TERM": "xterm", "LC_ALL": "C.utf8"}, true},
+		{"C locale", map[string]string{"TERM": "xterm", "LANG": "C"}, false},
+		{"LC_ALL wins", map[string]string{"TERM": "xterm", "LC_ALL": "POSIX", "LANG": "en_US.UTF-8"}, false},
+		{"serial console", map[string]string{"TERM": "vt100", "LANG": "en_US.UTF-8"}, false},
+		{"linux console", map[string]string{"TERM": "linux"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"TERM", "LC_ALL", "LC_CTYPE", "LANG"} {
+				t.Setenv(key, tt.env[key])
+			}
+
+			if got := UnicodeSupported(); got != tt.want {
+				t.Errorf("UnicodeSupported() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
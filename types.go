@@ -1,7 +1,9 @@
 package tap
 
 import (
+	"context"
 	"io"
+	"time"
 
 	"github.com/yarlson/tap/internal/terminal"
 )
@@ -10,23 +12,53 @@ import (
 
 // TextOptions defines options for styled text prompt.
 type TextOptions struct {
-	Message      string
-	Placeholder  string
-	DefaultValue string
-	InitialValue string
-	Validate     func(string) error
-	Input        Reader
-	Output       Writer
+	Message       string
+	Placeholder   string
+	DefaultValue  string
+	InitialValue  string
+	Validate      func(string) error
+	MaxLength     int           // maximum runes; shows a live counter when set
+	PasteNewlines NewlinePolicy // how line breaks in pasted text are handled
+	Theme         *Theme        // overrides the global theme
+	Locale        *Locale       // overrides the global locale
+	Input         Reader
+	Output        Writer
 }
 
 // PasswordOptions defines options for styled password prompt.
 type PasswordOptions struct {
-	Message      string
-	DefaultValue string
-	InitialValue string
-	Validate     func(string) error
-	Input        Reader
-	Output       Writer
+	Message         string
+	DefaultValue    string
+	InitialValue    string
+	Validate        func(string) error
+	ValidateSecret  func([]byte) error            // used by PasswordBytes in place of Validate
+	Mask            rune                          // mask glyph (default from the theme, '●')
+	Silent          bool                          // echo nothing while typing
+	AllowReveal     bool                          // Ctrl+R toggles showing the plain value
+	Strength        func(string) PasswordStrength // renders a strength meter under the input
+	ConfirmMessage  string                        // if set, ask again with this message; a mismatch restarts both entries
+	MismatchMessage string                        // error shown when the confirmation differs
+	PasteNewlines   NewlinePolicy                 // how line breaks in pasted text are handled
+	Theme           *Theme                        // overrides the global theme
+	Locale          *Locale                       // overrides the global locale
+	Input           Reader
+	Output          Writer
+}
+
+// NewlinePolicy controls how single-line prompts handle line breaks embedded
+// in pasted text. Trailing line breaks are always dropped.
+type NewlinePolicy int
+
+const (
+	NewlinesJoin   NewlinePolicy = iota // replace each line break with a space (default)
+	NewlinesStrip                       // remove line breaks
+	NewlinesReject                      // refuse the paste and show an error
+)
+
+// PasswordStrength is the result of a password strength estimator.
+type PasswordStrength struct {
+	Score int    // 0 (weakest) to 4 (strongest)
+	Label string // optional text shown next to the meter
 }
 
 // ConfirmOptions defines options for styled confirm prompt.
@@ -35,6 +67,8 @@ type ConfirmOptions struct {
 	Active       string
 	Inactive     string
 	InitialValue bool
+	Theme        *Theme  // overrides the global theme
+	Locale       *Locale // overrides the global locale
 	Input        Reader
 	Output       Writer
 }
@@ -52,6 +86,10 @@ type SelectOptions[T any] struct {
 	Options      []SelectOption[T]
 	InitialValue *T
 	MaxItems     *int
+	AltScreen    bool    // render full screen in the alternate screen, showing as many options as fit
+	Mouse        bool    // clicking an option selects it; the wheel moves the cursor
+	Theme        *Theme  // overrides the global theme
+	Locale       *Locale // overrides the global locale
 	Input        Reader
 	Output       Writer
 }
@@ -62,6 +100,10 @@ type MultiSelectOptions[T any] struct {
 	Options       []SelectOption[T]
 	InitialValues []T
 	MaxItems      *int
+	AltScreen     bool    // render full screen in the alternate screen, showing as many options as fit
+	Mouse         bool    // clicking an option toggles it; the wheel moves the cursor
+	Theme         *Theme  // overrides the global theme
+	Locale        *Locale // overrides the global locale
 	Input         Reader
 	Output        Writer
 }
@@ -73,21 +115,59 @@ type TextareaOptions struct {
 	DefaultValue string
 	InitialValue string
 	Validate     func(string) error
+	Editor       string                   // command for Ctrl+E; defaults to $VISUAL, then $EDITOR, then vi
+	MaxHeight    int                      // visible rows before scrolling; defaults to the terminal height
+	LineNumbers  bool                     // show a line-number gutter
+	IndentWidth  int                      // spaces inserted by Tab and removed by Shift+Tab (default 2)
+	Highlight    func(line string) string // styles each logical line; must only add ANSI sequences
+	MaxLength    int                      // maximum runes including pastes; shows a live counter when set
+	MaxLines     int                      // maximum lines; shows a live counter when set
+	Mouse        bool                     // clicking places the cursor; the wheel moves it between rows
+	Theme        *Theme                   // overrides the global theme
+	Locale       *Locale                  // overrides the global locale
 	Input        Reader
 	Output       Writer
 }
 
 // AutocompleteOptions defines options for styled autocomplete text prompt.
 type AutocompleteOptions struct {
-	Message      string
-	Placeholder  string
-	DefaultValue string
-	InitialValue string
-	Validate     func(string) error
-	Suggest      func(string) []string // returns suggestion list for current input
-	MaxResults   int                   // maximum suggestions to show (default 5)
-	Input        Reader
-	Output       Writer
+	Message       string
+	Placeholder   string
+	DefaultValue  string
+	InitialValue  string
+	Validate      func(string) error
+	Suggest       func(string) []string                                         // returns suggestion list for current input
+	SuggestItems  func(string) []Suggestion                                     // rich suggestions; used instead of Suggest
+	SuggestAsync  func(ctx context.Context, input string) ([]Suggestion, error) // runs off the event loop; cancelled when the input changes
+	Debounce      time.Duration                                                 // delay before calling SuggestAsync after input changes
+	MaxResults    int                                                           // maximum suggestions to show (default 5)
+	PasteNewlines NewlinePolicy                                                 // line-break handling for pastes
+	Theme         *Theme                                                        // overrides the global theme
+	Locale        *Locale                                                       // overrides the global locale
+	Input         Reader
+	Output        Writer
+}
+
+// Suggestion is a rich autocomplete entry: Value is inserted into the input,
+// Label (defaulting to Value) is displayed, followed by a dim Hint.
+type Suggestion struct {
+	Value string
+	Label string
+	Hint  string
+}
+
+// TagsOptions defines options for styled tag (chip) input prompt.
+type TagsOptions struct {
+	Message       string
+	Placeholder   string
+	InitialValues []string
+	Validate      func(string) error    // validates each tag before it is added
+	Suggest       func(string) []string // returns suggestions for the tag being typed
+	MaxResults    int                   // maximum suggestions to show (default 5)
+	Theme         *Theme                // overrides the global theme
+	Locale        *Locale               // overrides the global locale
+	Input         Reader
+	Output        Writer
 }
 
 type ClackState string
@@ -179,9 +259,10 @@ type TableOptions struct {
 	Output           Writer
 	ShowBorders      bool
 	IncludePrefix    bool
-	MaxWidth         int
+	MaxWidth         int // if 0, the Output's width or 80
 	ColumnAlignments []TableAlignment
 	HeaderStyle      TableStyle
 	HeaderColor      TableColor
 	FormatBorder     func(string) string
+	Theme            *Theme // overrides the global theme
 }